@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"task-manager/internal/infrastructure/app"
+	_ "time/tzdata"
 
 	"go.uber.org/zap"
 )
@@ -25,6 +27,22 @@ func main() {
 		}
 	}()
 
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	dispatchDone := make(chan struct{})
+	go func() {
+		defer close(dispatchDone)
+		// The ledger sink credits rewards while claiming and has no dispatcher.
+		if application.RewardDispatcher != nil {
+			application.RewardDispatcher.Run(backgroundCtx)
+		}
+	}()
+
+	expiryDone := make(chan struct{})
+	go func() {
+		defer close(expiryDone)
+		application.TaskService.RunSessionExpiry(backgroundCtx, application.Config.Tasks.SessionExpiryInterval)
+	}()
+
 	application.Log.Info("server is starting", zap.String("env", application.Config.Logger.Env))
 
 	quit := make(chan os.Signal, 1)
@@ -36,5 +54,8 @@ func main() {
 	}
 
 	application.GRPCServer.GracefulStop()
+	stopBackground()
+	<-dispatchDone
+	<-expiryDone
 	application.Log.Info("server stopped")
 }
@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+
+	"task-manager/internal/core/ports"
+	"task-manager/internal/mapper"
+	tasksv1 "task-manager/pkg/grpc/gen/tasks"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TaskAdminServer struct {
+	tasksv1.UnimplementedTaskAdminServiceServer
+	service ports.TaskAdminUseCases
+	log     *zap.Logger
+}
+
+func NewTaskAdminServer(service ports.TaskAdminUseCases, log *zap.Logger) *TaskAdminServer {
+	if log == nil {
+		panic("logger is nil")
+	}
+	if service == nil {
+		log.Fatal("task admin service is nil")
+	}
+	return &TaskAdminServer{
+		service: service,
+		log:     log,
+	}
+}
+
+func (s *TaskAdminServer) CreateTask(ctx context.Context, req *tasksv1.CreateTaskRequest) (*tasksv1.CreateTaskResponse, error) {
+	s.log.Info("grpc: create task", zap.String("title", req.GetTitle()), zap.String("type", req.GetType()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: create task validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	task, err := s.service.CreateTask(ctx, mapper.NewTask(req))
+	if err != nil {
+		s.log.Error("grpc: create task failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: create task done", zap.String("task_id", task.ID()))
+	return &tasksv1.CreateTaskResponse{Task: mapper.Task(task)}, nil
+}
+
+func (s *TaskAdminServer) UpdateTask(ctx context.Context, req *tasksv1.UpdateTaskRequest) (*tasksv1.UpdateTaskResponse, error) {
+	s.log.Info("grpc: update task", zap.String("task_id", req.GetTaskId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: update task validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	task, err := s.service.UpdateTask(ctx, mapper.UpdatedTask(req))
+	if err != nil {
+		s.log.Error("grpc: update task failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: update task done", zap.String("task_id", task.ID()))
+	return &tasksv1.UpdateTaskResponse{Task: mapper.Task(task)}, nil
+}
+
+func (s *TaskAdminServer) DeactivateTask(ctx context.Context, req *tasksv1.DeactivateTaskRequest) (*tasksv1.DeactivateTaskResponse, error) {
+	s.log.Info("grpc: deactivate task", zap.String("task_id", req.GetTaskId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: deactivate task validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	task, err := s.service.DeactivateTask(ctx, req.GetTaskId())
+	if err != nil {
+		s.log.Error("grpc: deactivate task failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: deactivate task done", zap.String("task_id", req.GetTaskId()))
+	return &tasksv1.DeactivateTaskResponse{Task: mapper.Task(task)}, nil
+}
+
+func (s *TaskAdminServer) ListTasks(ctx context.Context, req *tasksv1.ListTasksRequest) (*tasksv1.ListTasksResponse, error) {
+	s.log.Info("grpc: list tasks", zap.Bool("include_inactive", req.GetIncludeInactive()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: list tasks validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tasks, err := s.service.ListTasks(ctx, req.GetIncludeInactive())
+	if err != nil {
+		s.log.Error("grpc: list tasks failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: list tasks done", zap.Int("tasks", len(tasks)))
+	return &tasksv1.ListTasksResponse{Tasks: mapper.Tasks(tasks)}, nil
+}
+
+func (s *TaskAdminServer) CreateSeason(ctx context.Context, req *tasksv1.CreateSeasonRequest) (*tasksv1.CreateSeasonResponse, error) {
+	s.log.Info("grpc: create season", zap.String("name", req.GetName()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: create season validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	season, err := s.service.CreateSeason(ctx, mapper.NewSeason(req))
+	if err != nil {
+		s.log.Error("grpc: create season failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: create season done", zap.String("season_id", season.ID()))
+	return &tasksv1.CreateSeasonResponse{Season: mapper.Season(season)}, nil
+}
+
+func (s *TaskAdminServer) SetSeasonPremium(ctx context.Context, req *tasksv1.SetSeasonPremiumRequest) (*tasksv1.SetSeasonPremiumResponse, error) {
+	s.log.Info("grpc: set season premium", zap.String("user_id", req.GetUserId()), zap.String("season_id", req.GetSeasonId()), zap.Bool("premium", req.GetPremium()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: set season premium validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	season, progress, err := s.service.SetSeasonPremium(ctx, req.GetUserId(), req.GetSeasonId(), req.GetPremium())
+	if err != nil {
+		s.log.Error("grpc: set season premium failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: set season premium done", zap.String("user_id", req.GetUserId()), zap.String("season_id", req.GetSeasonId()))
+	return &tasksv1.SetSeasonPremiumResponse{Progress: mapper.SeasonProgress(season, progress)}, nil
+}
+
+func (s *TaskAdminServer) CreateAchievement(ctx context.Context, req *tasksv1.CreateAchievementRequest) (*tasksv1.CreateAchievementResponse, error) {
+	s.log.Info("grpc: create achievement", zap.String("title", req.GetTitle()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: create achievement validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	achievement, err := s.service.CreateAchievement(ctx, mapper.NewAchievement(req))
+	if err != nil {
+		s.log.Error("grpc: create achievement failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: create achievement done", zap.String("achievement_id", achievement.ID()))
+	return &tasksv1.CreateAchievementResponse{Achievement: mapper.Achievement(achievement)}, nil
+}
+
+func (s *TaskAdminServer) CreateGroup(ctx context.Context, req *tasksv1.CreateGroupRequest) (*tasksv1.CreateGroupResponse, error) {
+	s.log.Info("grpc: create group", zap.String("name", req.GetName()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: create group validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	group, err := s.service.CreateGroup(ctx, mapper.NewGroup(req))
+	if err != nil {
+		s.log.Error("grpc: create group failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: create group done", zap.String("group_id", group.ID()))
+	return &tasksv1.CreateGroupResponse{Group: mapper.Group(group)}, nil
+}
@@ -270,17 +270,294 @@ func (s *TaskServer) SubscribeProgress(req *tasksv1.SubscribeProgressRequest, st
 }
 
 func (s *TaskServer) ClaimReward(ctx context.Context, req *tasksv1.ClaimRewardRequest) (*tasksv1.ClaimRewardResponse, error) {
-	s.log.Info("grpc: claim reward", zap.String("user_id", req.GetUserId()), zap.String("task_id", req.GetTaskId()))
+	s.log.Info("grpc: claim reward", zap.String("user_id", req.GetUserId()), zap.String("task_id", req.GetTaskId()), zap.Int32("tier", req.GetTier()), zap.String("idempotency_key", req.GetIdempotencyKey()))
 	if err := req.ValidateAll(); err != nil {
 		s.log.Warn("grpc: claim reward validation failed", zap.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if err := s.service.ClaimReward(ctx, req.GetUserId(), req.GetTaskId()); err != nil {
+	claim, progress, err := s.service.ClaimReward(ctx, req.GetUserId(), req.GetTaskId(), int(req.GetTier()), req.GetIdempotencyKey())
+	if err != nil {
 		s.log.Error("grpc: claim reward failed", zap.Error(err))
 		return nil, mapper.Error(err)
 	}
 
-	s.log.Info("grpc: claim reward done", zap.String("user_id", req.GetUserId()), zap.String("task_id", req.GetTaskId()))
-	return &tasksv1.ClaimRewardResponse{}, nil
+	s.log.Info("grpc: claim reward done", zap.String("user_id", req.GetUserId()), zap.String("task_id", req.GetTaskId()), zap.Bool("replayed", claim.Replayed))
+	return mapper.ClaimRewardResponse(claim, progress), nil
+}
+
+func (s *TaskServer) ClaimAllRewards(ctx context.Context, req *tasksv1.ClaimAllRewardsRequest) (*tasksv1.ClaimAllRewardsResponse, error) {
+	s.log.Info("grpc: claim all rewards", zap.String("user_id", req.GetUserId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: claim all rewards validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, total, err := s.service.ClaimAllRewards(ctx, req.GetUserId())
+	if err != nil {
+		s.log.Error("grpc: claim all rewards failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: claim all rewards done", zap.String("user_id", req.GetUserId()), zap.Int("tasks", len(results)))
+	return mapper.ClaimAllRewardsResponse(results, total), nil
+}
+
+func (s *TaskServer) SetUserTimezone(ctx context.Context, req *tasksv1.SetUserTimezoneRequest) (*tasksv1.SetUserTimezoneResponse, error) {
+	s.log.Info("grpc: set user timezone", zap.String("user_id", req.GetUserId()), zap.String("timezone", req.GetTimezone()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: set user timezone validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	settings, err := s.service.SetUserTimezone(ctx, req.GetUserId(), req.GetTimezone())
+	if err != nil {
+		s.log.Error("grpc: set user timezone failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: set user timezone done", zap.String("user_id", req.GetUserId()))
+	return mapper.SetUserTimezoneResponse(settings), nil
+}
+
+func (s *TaskServer) GetSeason(ctx context.Context, req *tasksv1.GetSeasonRequest) (*tasksv1.GetSeasonResponse, error) {
+	s.log.Debug("grpc: get season", zap.String("user_id", req.GetUserId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: get season validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	season, progress, err := s.service.GetSeason(ctx, req.GetUserId())
+	if err != nil {
+		s.log.Error("grpc: get season failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Debug("grpc: get season done", zap.String("season_id", season.ID()))
+	return &tasksv1.GetSeasonResponse{
+		Season:   mapper.Season(season),
+		Progress: mapper.SeasonProgress(season, progress),
+	}, nil
+}
+
+func (s *TaskServer) ClaimSeasonReward(ctx context.Context, req *tasksv1.ClaimSeasonRewardRequest) (*tasksv1.ClaimSeasonRewardResponse, error) {
+	s.log.Info("grpc: claim season reward", zap.String("user_id", req.GetUserId()), zap.String("season_id", req.GetSeasonId()), zap.Int32("level", req.GetLevel()), zap.String("track", req.GetTrack()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: claim season reward validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	season, progress, grant, err := s.service.ClaimSeasonReward(ctx, req.GetUserId(), req.GetSeasonId(), int(req.GetLevel()), entities.SeasonTrack(req.GetTrack()))
+	if err != nil {
+		s.log.Error("grpc: claim season reward failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: claim season reward done", zap.String("user_id", req.GetUserId()), zap.String("season_id", req.GetSeasonId()))
+	return mapper.ClaimSeasonRewardResponse(season, progress, grant), nil
+}
+
+func (s *TaskServer) ListAchievements(ctx context.Context, req *tasksv1.ListAchievementsRequest) (*tasksv1.ListAchievementsResponse, error) {
+	s.log.Debug("grpc: list achievements", zap.String("user_id", req.GetUserId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: list achievements validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	achievements, err := s.service.ListAchievements(ctx, req.GetUserId())
+	if err != nil {
+		s.log.Error("grpc: list achievements failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	resp := &tasksv1.ListAchievementsResponse{
+		Achievements: make([]*tasksv1.UserAchievement, 0, len(achievements)),
+	}
+	for _, achievement := range achievements {
+		resp.Achievements = append(resp.Achievements, mapper.UserAchievement(achievement))
+	}
+	s.log.Debug("grpc: list achievements done", zap.Int("count", len(resp.Achievements)))
+	return resp, nil
+}
+
+func (s *TaskServer) GetRoomProgress(ctx context.Context, req *tasksv1.GetRoomProgressRequest) (*tasksv1.GetRoomProgressResponse, error) {
+	s.log.Debug("grpc: get room progress", zap.String("task_id", req.GetTaskId()), zap.String("room_id", req.GetRoomId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: get room progress validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	progress, err := s.service.GetRoomProgress(ctx, req.GetTaskId(), req.GetRoomId())
+	if err != nil {
+		s.log.Error("grpc: get room progress failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Debug("grpc: get room progress done", zap.Int("contributors", len(progress.Contributions)))
+	return &tasksv1.GetRoomProgressResponse{Progress: mapper.RoomProgress(progress)}, nil
+}
+
+func (s *TaskServer) JoinGroup(ctx context.Context, req *tasksv1.JoinGroupRequest) (*tasksv1.JoinGroupResponse, error) {
+	s.log.Info("grpc: join group", zap.String("user_id", req.GetUserId()), zap.String("group_id", req.GetGroupId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: join group validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	member, err := s.service.JoinGroup(ctx, req.GetUserId(), req.GetGroupId())
+	if err != nil {
+		s.log.Error("grpc: join group failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: join group done", zap.String("user_id", req.GetUserId()), zap.String("group_id", req.GetGroupId()))
+	return &tasksv1.JoinGroupResponse{Member: mapper.GroupMember(*member)}, nil
+}
+
+func (s *TaskServer) LeaveGroup(ctx context.Context, req *tasksv1.LeaveGroupRequest) (*tasksv1.LeaveGroupResponse, error) {
+	s.log.Info("grpc: leave group", zap.String("user_id", req.GetUserId()), zap.String("group_id", req.GetGroupId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: leave group validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.service.LeaveGroup(ctx, req.GetUserId(), req.GetGroupId()); err != nil {
+		s.log.Error("grpc: leave group failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: leave group done", zap.String("user_id", req.GetUserId()), zap.String("group_id", req.GetGroupId()))
+	return &tasksv1.LeaveGroupResponse{UserId: req.GetUserId(), GroupId: req.GetGroupId()}, nil
+}
+
+func (s *TaskServer) ListGroupMembers(ctx context.Context, req *tasksv1.ListGroupMembersRequest) (*tasksv1.ListGroupMembersResponse, error) {
+	s.log.Debug("grpc: list group members", zap.String("group_id", req.GetGroupId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: list group members validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	group, members, err := s.service.ListGroupMembers(ctx, req.GetGroupId())
+	if err != nil {
+		s.log.Error("grpc: list group members failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	resp := &tasksv1.ListGroupMembersResponse{
+		Group:   mapper.Group(group),
+		Members: make([]*tasksv1.GroupMember, 0, len(members)),
+	}
+	for _, member := range members {
+		resp.Members = append(resp.Members, mapper.GroupMember(member))
+	}
+	s.log.Debug("grpc: list group members done", zap.Int("count", len(resp.Members)))
+	return resp, nil
+}
+
+func (s *TaskServer) GetGroupProgress(ctx context.Context, req *tasksv1.GetGroupProgressRequest) (*tasksv1.GetGroupProgressResponse, error) {
+	s.log.Debug("grpc: get group progress", zap.String("task_id", req.GetTaskId()), zap.String("group_id", req.GetGroupId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: get group progress validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	progress, err := s.service.GetGroupProgress(ctx, req.GetTaskId(), req.GetGroupId())
+	if err != nil {
+		s.log.Error("grpc: get group progress failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Debug("grpc: get group progress done", zap.Int("contributors", len(progress.Contributions)))
+	return &tasksv1.GetGroupProgressResponse{Progress: mapper.RoomProgress(progress)}, nil
+}
+
+func (s *TaskServer) GetLeaderboard(ctx context.Context, req *tasksv1.GetLeaderboardRequest) (*tasksv1.GetLeaderboardResponse, error) {
+	s.log.Debug("grpc: get leaderboard", zap.String("task_id", req.GetTaskId()), zap.String("period_key", req.GetPeriodKey()), zap.Int32("limit", req.GetLimit()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: get leaderboard validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	board, err := s.service.GetLeaderboard(ctx, req.GetTaskId(), req.GetPeriodKey(), int(req.GetLimit()))
+	if err != nil {
+		s.log.Error("grpc: get leaderboard failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Debug("grpc: get leaderboard done", zap.Int("entries", len(board.Entries)))
+	return mapper.LeaderboardResponse(board), nil
+}
+
+func (s *TaskServer) GetLeaderboardAroundUser(ctx context.Context, req *tasksv1.GetLeaderboardAroundUserRequest) (*tasksv1.GetLeaderboardResponse, error) {
+	s.log.Debug("grpc: get leaderboard around user", zap.String("task_id", req.GetTaskId()), zap.String("user_id", req.GetUserId()), zap.String("period_key", req.GetPeriodKey()), zap.Int32("radius", req.GetRadius()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: get leaderboard around user validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	board, err := s.service.GetLeaderboardAroundUser(ctx, req.GetTaskId(), req.GetUserId(), req.GetPeriodKey(), int(req.GetRadius()))
+	if err != nil {
+		s.log.Error("grpc: get leaderboard around user failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Debug("grpc: get leaderboard around user done", zap.Int("entries", len(board.Entries)))
+	return mapper.LeaderboardResponse(board), nil
+}
+
+func (s *TaskServer) GetBalances(ctx context.Context, req *tasksv1.GetBalancesRequest) (*tasksv1.GetBalancesResponse, error) {
+	s.log.Debug("grpc: get balances", zap.String("user_id", req.GetUserId()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: get balances validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	balances, err := s.service.GetBalances(ctx, req.GetUserId())
+	if err != nil {
+		s.log.Error("grpc: get balances failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Debug("grpc: get balances done", zap.Int("balances", len(balances)))
+	return mapper.BalancesResponse(req.GetUserId(), balances), nil
+}
+
+func (s *TaskServer) ListLedger(ctx context.Context, req *tasksv1.ListLedgerRequest) (*tasksv1.ListLedgerResponse, error) {
+	s.log.Debug("grpc: list ledger", zap.String("user_id", req.GetUserId()), zap.String("page_token", req.GetPageToken()), zap.Int32("limit", req.GetLimit()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: list ledger validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	transactions, nextPageToken, err := s.service.ListLedger(ctx, req.GetUserId(), req.GetPageToken(), int(req.GetLimit()))
+	if err != nil {
+		s.log.Error("grpc: list ledger failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Debug("grpc: list ledger done", zap.Int("transactions", len(transactions)))
+	return &tasksv1.ListLedgerResponse{
+		Transactions:  mapper.LedgerTransactions(transactions),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *TaskServer) SpendCurrency(ctx context.Context, req *tasksv1.SpendCurrencyRequest) (*tasksv1.SpendCurrencyResponse, error) {
+	s.log.Info("grpc: spend currency", zap.String("user_id", req.GetUserId()), zap.String("currency", req.GetCurrency()), zap.Int64("amount", req.GetAmount()))
+	if err := req.ValidateAll(); err != nil {
+		s.log.Warn("grpc: spend currency validation failed", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tx, balance, err := s.service.SpendCurrency(ctx, req.GetUserId(), req.GetCurrency(), req.GetAmount(), req.GetReference())
+	if err != nil {
+		s.log.Error("grpc: spend currency failed", zap.Error(err))
+		return nil, mapper.Error(err)
+	}
+
+	s.log.Info("grpc: spend currency done", zap.String("transaction_id", tx.ID))
+	return &tasksv1.SpendCurrencyResponse{
+		Transaction: mapper.LedgerTransaction(tx),
+		Balance:     mapper.Balance(balance),
+	}, nil
 }
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"task-manager/internal/core/domain/entities"
+
+	"go.uber.org/zap"
+)
+
+// RewardSink keeps delivered grants in memory. It stands in for a real reward
+// backend in local development.
+type RewardSink struct {
+	mu     sync.Mutex
+	grants []entities.RewardGrant
+	seen   map[string]struct{}
+	log    *zap.Logger
+}
+
+func NewRewardSink(log *zap.Logger) *RewardSink {
+	if log == nil {
+		panic("logger is nil")
+	}
+	return &RewardSink{
+		seen: make(map[string]struct{}),
+		log:  log,
+	}
+}
+
+// Deliver records the grant once; redeliveries of the same grant are ignored.
+func (s *RewardSink) Deliver(ctx context.Context, grant *entities.RewardGrant) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seen[grant.ID]; ok {
+		return nil
+	}
+	s.seen[grant.ID] = struct{}{}
+	s.grants = append(s.grants, *grant)
+	s.log.Info("reward delivered in memory",
+		zap.String("grant_id", grant.ID),
+		zap.String("user_id", grant.UserID),
+		zap.String("source", string(grant.Source)),
+		zap.String("source_id", grant.SourceID),
+		zap.ByteString("reward", grant.Reward),
+	)
+	return nil
+}
+
+// Delivered returns the grants delivered so far, oldest first.
+func (s *RewardSink) Delivered() []entities.RewardGrant {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entities.RewardGrant(nil), s.grants...)
+}
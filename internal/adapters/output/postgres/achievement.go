@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/infrastructure/db"
+
+	"go.uber.org/zap"
+)
+
+const achievementColumns = `id, title, description, badge, criteria, reward, created_at`
+
+type AchievementRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewAchievementRepository(db db.Querier, log *zap.Logger) *AchievementRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &AchievementRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *AchievementRepository) List(ctx context.Context) ([]*entities.Achievement, error) {
+	query := `SELECT ` + achievementColumns + ` FROM achievements ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to list achievements", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var achievements []*entities.Achievement
+	for rows.Next() {
+		achievement, err := scanAchievement(rows)
+		if err != nil {
+			r.log.Error("failed to scan achievement", zap.Error(err))
+			return nil, err
+		}
+		achievements = append(achievements, achievement)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate achievements", zap.Error(err))
+		return nil, err
+	}
+	return achievements, nil
+}
+
+func (r *AchievementRepository) Create(ctx context.Context, achievement *entities.Achievement) error {
+	query := `INSERT INTO achievements (title, description, badge, criteria, reward)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	criteria, err := json.Marshal(achievement.Criteria())
+	if err != nil {
+		r.log.Error("failed to marshal achievement criteria", zap.Error(err))
+		return err
+	}
+
+	var (
+		id        string
+		createdAt time.Time
+	)
+	if err := r.db.QueryRow(ctx, query,
+		achievement.Title(),
+		nullableString(achievement.Description()),
+		nullableString(achievement.Badge()),
+		criteria,
+		nullableJSON(achievement.Reward()),
+	).Scan(&id, &createdAt); err != nil {
+		r.log.Error("failed to create achievement", zap.Error(err))
+		return err
+	}
+	achievement.SetID(id)
+	achievement.SetCreatedAt(createdAt)
+	return nil
+}
+
+func (r *AchievementRepository) ListUnlocked(ctx context.Context, userID string) (map[string]time.Time, error) {
+	query := `SELECT achievement_id, unlocked_at FROM user_achievements WHERE user_id = $1`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("failed to list unlocked achievements", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	unlocked := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			id         string
+			unlockedAt time.Time
+		)
+		if err := rows.Scan(&id, &unlockedAt); err != nil {
+			r.log.Error("failed to scan unlocked achievement", zap.Error(err))
+			return nil, err
+		}
+		unlocked[id] = unlockedAt
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate unlocked achievements", zap.Error(err))
+		return nil, err
+	}
+	return unlocked, nil
+}
+
+func (r *AchievementRepository) Unlock(ctx context.Context, userID string, achievementID string, unlockedAt time.Time) (bool, error) {
+	query := `INSERT INTO user_achievements (user_id, achievement_id, unlocked_at)
+		VALUES ($1, $2, COALESCE($3, NOW()))
+		ON CONFLICT (user_id, achievement_id) DO NOTHING`
+
+	tag, err := r.db.Exec(ctx, query, userID, achievementID, nullableTime(unlockedAt))
+	if err != nil {
+		r.log.Error("failed to unlock achievement", zap.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *AchievementRepository) GetCounters(ctx context.Context, userID string) (map[string]int64, error) {
+	query := `SELECT counter, value FROM user_counters WHERE user_id = $1`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("failed to get user counters", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	counters := make(map[string]int64)
+	for rows.Next() {
+		var (
+			counter string
+			value   int64
+		)
+		if err := rows.Scan(&counter, &value); err != nil {
+			r.log.Error("failed to scan user counter", zap.Error(err))
+			return nil, err
+		}
+		counters[counter] = value
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate user counters", zap.Error(err))
+		return nil, err
+	}
+	return counters, nil
+}
+
+func (r *AchievementRepository) IncrementCounters(ctx context.Context, userID string, counters []string, updatedAt time.Time) error {
+	if len(counters) == 0 {
+		return nil
+	}
+	query := `INSERT INTO user_counters (user_id, counter, value, updated_at)
+		SELECT $1, counter, COUNT(*), COALESCE($3, NOW())
+		FROM unnest($2::text[]) AS counter
+		GROUP BY counter
+		ON CONFLICT (user_id, counter) DO UPDATE
+		SET value = user_counters.value + EXCLUDED.value,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.Exec(ctx, query, userID, counters, nullableTime(updatedAt)); err != nil {
+		r.log.Error("failed to increment user counters", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *AchievementRepository) MarkTaskCompleted(ctx context.Context, userID string, taskID string, completedAt time.Time) (bool, error) {
+	query := `INSERT INTO user_completed_tasks (user_id, task_id, completed_at)
+		VALUES ($1, $2, COALESCE($3, NOW()))
+		ON CONFLICT (user_id, task_id) DO NOTHING`
+
+	tag, err := r.db.Exec(ctx, query, userID, taskID, nullableTime(completedAt))
+	if err != nil {
+		r.log.Error("failed to mark task completed", zap.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *AchievementRepository) ListCompletedTasks(ctx context.Context, userID string) (map[string]time.Time, error) {
+	query := `SELECT task_id, completed_at FROM user_completed_tasks WHERE user_id = $1`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("failed to list completed tasks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	completed := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			taskID      string
+			completedAt time.Time
+		)
+		if err := rows.Scan(&taskID, &completedAt); err != nil {
+			r.log.Error("failed to scan completed task", zap.Error(err))
+			return nil, err
+		}
+		completed[taskID] = completedAt
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate completed tasks", zap.Error(err))
+		return nil, err
+	}
+	return completed, nil
+}
+
+func scanAchievement(row rowScanner) (*entities.Achievement, error) {
+	var (
+		id           string
+		title        string
+		description  sql.NullString
+		badge        sql.NullString
+		criteriaJSON []byte
+		reward       []byte
+		createdAt    time.Time
+	)
+	if err := row.Scan(&id, &title, &description, &badge, &criteriaJSON, &reward, &createdAt); err != nil {
+		return nil, err
+	}
+
+	var criteria []entities.AchievementCriterion
+	if err := json.Unmarshal(criteriaJSON, &criteria); err != nil {
+		return nil, err
+	}
+	return entities.NewAchievement(id, title, description.String, badge.String, criteria, reward, createdAt), nil
+}
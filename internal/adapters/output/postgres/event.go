@@ -10,6 +10,13 @@ import (
 	"go.uber.org/zap"
 )
 
+// eventPayload is the JSON stored in task_events.payload. Progress fields keep
+// the original {task_id, amount} layout; attributes are added alongside.
+type eventPayload struct {
+	*entities.ProgressPayload
+	Attributes entities.EventAttributes `json:"attributes,omitempty"`
+}
+
 type EventRepository struct {
 	db  db.Querier
 	log *zap.Logger
@@ -44,8 +51,8 @@ func (r *EventRepository) MarkProcessed(ctx context.Context, event *entities.Tas
 		ON CONFLICT (event_id) DO NOTHING`
 
 	payload := any(nil)
-	if payloadValue := event.Payload(); payloadValue != nil {
-		payloadBytes, err := json.Marshal(payloadValue)
+	if payloadValue, attributes := event.Payload(), event.RawAttributes(); payloadValue != nil || len(attributes) > 0 {
+		payloadBytes, err := json.Marshal(eventPayload{ProgressPayload: payloadValue, Attributes: attributes})
 		if err != nil {
 			r.log.Error("failed to marshal event payload", zap.Error(err))
 			return err
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type GroupRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewGroupRepository(db db.Querier, log *zap.Logger) *GroupRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &GroupRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *GroupRepository) Create(ctx context.Context, group *entities.Group) error {
+	query := `INSERT INTO groups (name) VALUES ($1) RETURNING id, created_at`
+
+	var (
+		id        string
+		createdAt time.Time
+	)
+	if err := r.db.QueryRow(ctx, query, group.Name()).Scan(&id, &createdAt); err != nil {
+		r.log.Error("failed to create group", zap.Error(err))
+		return err
+	}
+	group.SetID(id)
+	group.SetCreatedAt(createdAt)
+	return nil
+}
+
+func (r *GroupRepository) GetByID(ctx context.Context, id string) (*entities.Group, error) {
+	query := `SELECT id, name, created_at FROM groups WHERE id = $1`
+
+	var (
+		groupID   string
+		name      string
+		createdAt time.Time
+	)
+	if err := r.db.QueryRow(ctx, query, id).Scan(&groupID, &name, &createdAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrGroupNotFound
+		}
+		r.log.Error("failed to get group", zap.Error(err))
+		return nil, err
+	}
+	return entities.NewGroup(groupID, name, createdAt), nil
+}
+
+// Join is idempotent for a user who already belongs to the same group.
+func (r *GroupRepository) Join(ctx context.Context, groupID string, userID string, joinedAt time.Time) (*entities.GroupMember, error) {
+	query := `INSERT INTO group_members (user_id, group_id, joined_at)
+		VALUES ($1, $2, COALESCE($3, NOW()))
+		ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING group_id = $2::uuid, joined_at`
+
+	var (
+		sameGroup bool
+		member    = &entities.GroupMember{GroupID: groupID, UserID: userID}
+	)
+	if err := r.db.QueryRow(ctx, query, userID, groupID, nullableTime(joinedAt)).Scan(&sameGroup, &member.JoinedAt); err != nil {
+		r.log.Error("failed to join group", zap.Error(err))
+		return nil, err
+	}
+	if !sameGroup {
+		return nil, exceptions.ErrGroupMembershipExists
+	}
+	return member, nil
+}
+
+func (r *GroupRepository) Leave(ctx context.Context, groupID string, userID string) error {
+	query := `DELETE FROM group_members WHERE user_id = $1 AND group_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, userID, groupID)
+	if err != nil {
+		r.log.Error("failed to leave group", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return exceptions.ErrGroupMemberNotFound
+	}
+	return nil
+}
+
+func (r *GroupRepository) ListMembers(ctx context.Context, groupID string) ([]entities.GroupMember, error) {
+	query := `SELECT user_id, joined_at FROM group_members
+		WHERE group_id = $1
+		ORDER BY joined_at, user_id`
+
+	rows, err := r.db.Query(ctx, query, groupID)
+	if err != nil {
+		r.log.Error("failed to list group members", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []entities.GroupMember
+	for rows.Next() {
+		member := entities.GroupMember{GroupID: groupID}
+		if err := rows.Scan(&member.UserID, &member.JoinedAt); err != nil {
+			r.log.Error("failed to scan group member", zap.Error(err))
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate group members", zap.Error(err))
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *GroupRepository) GetUserGroup(ctx context.Context, userID string) (string, error) {
+	query := `SELECT group_id::text FROM group_members WHERE user_id = $1`
+
+	var groupID string
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&groupID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", exceptions.ErrGroupMemberNotFound
+		}
+		r.log.Error("failed to get user group", zap.Error(err))
+		return "", err
+	}
+	return groupID, nil
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// Leaderboard order: higher score first, then earlier tie_at, then user_id so
+// that ranks are stable. idx_leaderboard_scores_rank serves every query below.
+const (
+	leaderboardOrder = `score DESC, tie_at, user_id`
+	leaderboardAbove = `(score > $3 OR (score = $3 AND (tie_at, user_id) < ($4, $5)))`
+	leaderboardBelow = `(score < $3 OR (score = $3 AND (tie_at, user_id) > ($4, $5)))`
+)
+
+type LeaderboardRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewLeaderboardRepository(db db.Querier, log *zap.Logger) *LeaderboardRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &LeaderboardRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Record applies the update in one upsert, so concurrent updates of an entry
+// that does not exist yet are not lost. The score expression is repeated
+// because SET clauses only see the old row.
+func (r *LeaderboardRepository) Record(ctx context.Context, taskID string, periodKey string, userID string, value int, mode entities.AggregationMode, target int, at time.Time) error {
+	query := `INSERT INTO leaderboard_scores (task_id, period_key, user_id, score, completed_at, tie_at)
+		VALUES ($1, $2, $3, $4::bigint, CASE WHEN $4::bigint >= $6::bigint THEN $7::timestamptz END, $7::timestamptz)
+		ON CONFLICT (task_id, period_key, user_id) DO UPDATE
+		SET score = CASE WHEN $5::bool THEN GREATEST(leaderboard_scores.score, EXCLUDED.score)
+				ELSE leaderboard_scores.score + EXCLUDED.score END,
+			completed_at = COALESCE(leaderboard_scores.completed_at, CASE
+				WHEN (CASE WHEN $5::bool THEN GREATEST(leaderboard_scores.score, EXCLUDED.score)
+					ELSE leaderboard_scores.score + EXCLUDED.score END) >= $6::bigint THEN EXCLUDED.tie_at END),
+			tie_at = COALESCE(leaderboard_scores.completed_at, CASE
+				WHEN (CASE WHEN $5::bool THEN GREATEST(leaderboard_scores.score, EXCLUDED.score)
+					ELSE leaderboard_scores.score + EXCLUDED.score END) >= $6::bigint THEN EXCLUDED.tie_at END,
+				EXCLUDED.tie_at)
+		WHERE (CASE WHEN $5::bool THEN GREATEST(leaderboard_scores.score, EXCLUDED.score)
+			ELSE leaderboard_scores.score + EXCLUDED.score END) <> leaderboard_scores.score`
+
+	score := int64(value)
+	maxMode := mode == entities.AggregationMax
+	if maxMode {
+		score = max(score, 0)
+	}
+	if _, err := r.db.Exec(ctx, query, taskID, periodKey, userID, score, maxMode, target, at); err != nil {
+		r.log.Error("failed to record leaderboard entry", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *LeaderboardRepository) Top(ctx context.Context, taskID string, periodKey string, limit int) ([]entities.LeaderboardEntry, error) {
+	query := `SELECT user_id, score, completed_at, tie_at
+		FROM leaderboard_scores
+		WHERE task_id = $1 AND period_key = $2
+		ORDER BY ` + leaderboardOrder + `
+		LIMIT $3`
+
+	entries, err := r.list(ctx, query, taskID, periodKey, limit)
+	if err != nil {
+		r.log.Error("failed to list leaderboard top", zap.Error(err))
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries, nil
+}
+
+// Around ranks the user by counting the entries ordered before them, then reads
+// the neighbours on each side, so it never scans the whole bucket.
+func (r *LeaderboardRepository) Around(ctx context.Context, taskID string, periodKey string, userID string, radius int) ([]entities.LeaderboardEntry, error) {
+	meQuery := `SELECT score, completed_at, tie_at
+		FROM leaderboard_scores
+		WHERE task_id = $1 AND period_key = $2 AND user_id = $3`
+
+	me, err := r.getEntry(ctx, meQuery, taskID, periodKey, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrProgressNotFound
+		}
+		r.log.Error("failed to get leaderboard entry", zap.Error(err))
+		return nil, err
+	}
+
+	rankQuery := `SELECT COUNT(*) FROM leaderboard_scores
+		WHERE task_id = $1 AND period_key = $2 AND ` + leaderboardAbove
+
+	var ahead int
+	if err := r.db.QueryRow(ctx, rankQuery, taskID, periodKey, me.Score, me.TieAt, userID).Scan(&ahead); err != nil {
+		r.log.Error("failed to rank leaderboard entry", zap.Error(err))
+		return nil, err
+	}
+	me.Rank = ahead + 1
+
+	aboveQuery := `SELECT user_id, score, completed_at, tie_at
+		FROM leaderboard_scores
+		WHERE task_id = $1 AND period_key = $2 AND ` + leaderboardAbove + `
+		ORDER BY score, tie_at DESC, user_id DESC
+		LIMIT $6`
+
+	above, err := r.list(ctx, aboveQuery, taskID, periodKey, me.Score, me.TieAt, userID, radius)
+	if err != nil {
+		r.log.Error("failed to list leaderboard entries above", zap.Error(err))
+		return nil, err
+	}
+	slices.Reverse(above)
+	for i := range above {
+		above[i].Rank = me.Rank - len(above) + i
+	}
+
+	belowQuery := `SELECT user_id, score, completed_at, tie_at
+		FROM leaderboard_scores
+		WHERE task_id = $1 AND period_key = $2 AND ` + leaderboardBelow + `
+		ORDER BY ` + leaderboardOrder + `
+		LIMIT $6`
+
+	below, err := r.list(ctx, belowQuery, taskID, periodKey, me.Score, me.TieAt, userID, radius)
+	if err != nil {
+		r.log.Error("failed to list leaderboard entries below", zap.Error(err))
+		return nil, err
+	}
+	for i := range below {
+		below[i].Rank = me.Rank + i + 1
+	}
+
+	entries := make([]entities.LeaderboardEntry, 0, len(above)+1+len(below))
+	entries = append(entries, above...)
+	entries = append(entries, *me)
+	return append(entries, below...), nil
+}
+
+func (r *LeaderboardRepository) Count(ctx context.Context, taskID string, periodKey string) (int, error) {
+	query := `SELECT COUNT(*) FROM leaderboard_scores WHERE task_id = $1 AND period_key = $2`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, taskID, periodKey).Scan(&count); err != nil {
+		r.log.Error("failed to count leaderboard entries", zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *LeaderboardRepository) getEntry(ctx context.Context, query string, taskID string, periodKey string, userID string) (*entities.LeaderboardEntry, error) {
+	entry := entities.NewLeaderboardEntry(taskID, periodKey, userID)
+	var completedAt *time.Time
+	if err := r.db.QueryRow(ctx, query, taskID, periodKey, userID).Scan(&entry.Score, &completedAt, &entry.TieAt); err != nil {
+		return nil, err
+	}
+	if completedAt != nil {
+		entry.CompletedAt = *completedAt
+	}
+	return entry, nil
+}
+
+func (r *LeaderboardRepository) list(ctx context.Context, query string, taskID string, periodKey string, args ...any) ([]entities.LeaderboardEntry, error) {
+	rows, err := r.db.Query(ctx, query, append([]any{taskID, periodKey}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []entities.LeaderboardEntry
+	for rows.Next() {
+		entry := entities.LeaderboardEntry{TaskID: taskID, PeriodKey: periodKey}
+		var completedAt *time.Time
+		if err := rows.Scan(&entry.UserID, &entry.Score, &completedAt, &entry.TieAt); err != nil {
+			return nil, err
+		}
+		if completedAt != nil {
+			entry.CompletedAt = *completedAt
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// pgerrcodeInvalidTextRepresentation is raised when a parameter does not parse
+// as the column type, e.g. a malformed UUID.
+const pgerrcodeInvalidTextRepresentation = "22P02"
+
+type LedgerRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewLedgerRepository(db db.Querier, log *zap.Logger) *LedgerRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &LedgerRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// Post must run inside a transaction so that a rejected entry rolls back the
+// entries written before it.
+func (r *LedgerRepository) Post(ctx context.Context, tx *entities.LedgerTransaction) error {
+	query := `INSERT INTO ledger_transactions (user_id, kind, reference, created_at)
+		VALUES ($1, $2, $3, COALESCE($4, NOW()))
+		ON CONFLICT (user_id, kind, reference) DO NOTHING
+		RETURNING id, created_at`
+
+	if err := r.db.QueryRow(ctx, query, tx.UserID, tx.Kind, tx.Reference, nullableTime(tx.CreatedAt)).Scan(&tx.ID, &tx.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return exceptions.ErrLedgerReferenceExists
+		}
+		r.log.Error("failed to create ledger transaction", zap.Error(err))
+		return err
+	}
+
+	entryQuery := `INSERT INTO ledger_entries (transaction_id, account, system, asset_kind, asset, amount)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, entry := range tx.Entries {
+		if _, err := r.db.Exec(ctx, entryQuery, tx.ID, entry.Account, entry.System, entry.AssetKind, entry.Asset, entry.Amount); err != nil {
+			r.log.Error("failed to create ledger entry", zap.Error(err))
+			return err
+		}
+		if entry.System {
+			continue
+		}
+		if err := r.applyBalance(ctx, entry, tx.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *LedgerRepository) applyBalance(ctx context.Context, entry entities.LedgerEntry, updatedAt time.Time) error {
+	if entry.Amount >= 0 {
+		query := `INSERT INTO ledger_balances (user_id, asset_kind, asset, amount, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, asset_kind, asset) DO UPDATE
+			SET amount = ledger_balances.amount + EXCLUDED.amount,
+				updated_at = EXCLUDED.updated_at`
+
+		if _, err := r.db.Exec(ctx, query, entry.Account, entry.AssetKind, entry.Asset, entry.Amount, updatedAt); err != nil {
+			r.log.Error("failed to credit ledger balance", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	query := `UPDATE ledger_balances
+		SET amount = amount + $4, updated_at = $5
+		WHERE user_id = $1 AND asset_kind = $2 AND asset = $3 AND amount + $4 >= 0`
+
+	tag, err := r.db.Exec(ctx, query, entry.Account, entry.AssetKind, entry.Asset, entry.Amount, updatedAt)
+	if err != nil {
+		r.log.Error("failed to debit ledger balance", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return exceptions.ErrInsufficientBalance
+	}
+	return nil
+}
+
+func (r *LedgerRepository) ListBalances(ctx context.Context, userID string) ([]entities.Balance, error) {
+	query := `SELECT asset_kind, asset, amount, updated_at
+		FROM ledger_balances
+		WHERE user_id = $1
+		ORDER BY asset_kind, asset`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("failed to list ledger balances", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make([]entities.Balance, 0)
+	for rows.Next() {
+		balance := entities.Balance{UserID: userID}
+		if err := rows.Scan(&balance.AssetKind, &balance.Asset, &balance.Amount, &balance.UpdatedAt); err != nil {
+			r.log.Error("failed to scan ledger balance row", zap.Error(err))
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate ledger balance rows", zap.Error(err))
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *LedgerRepository) GetBalance(ctx context.Context, userID string, kind entities.AssetKind, asset string) (entities.Balance, error) {
+	query := `SELECT amount, updated_at
+		FROM ledger_balances
+		WHERE user_id = $1 AND asset_kind = $2 AND asset = $3`
+
+	balance := entities.Balance{UserID: userID, AssetKind: kind, Asset: asset}
+	if err := r.db.QueryRow(ctx, query, userID, kind, asset).Scan(&balance.Amount, &balance.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return balance, nil
+		}
+		r.log.Error("failed to get ledger balance", zap.Error(err))
+		return entities.Balance{}, err
+	}
+	return balance, nil
+}
+
+func (r *LedgerRepository) ListTransactions(ctx context.Context, userID string, afterID string, limit int) ([]*entities.LedgerTransaction, error) {
+	var afterAt, after any
+	if afterID != "" {
+		cursor, err := r.transactionTime(ctx, userID, afterID)
+		if err != nil {
+			return nil, err
+		}
+		afterAt, after = cursor, afterID
+	}
+
+	query := `SELECT id, kind, reference, created_at
+		FROM ledger_transactions
+		WHERE user_id = $1
+			AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4`
+
+	rows, err := r.db.Query(ctx, query, userID, afterAt, after, limit)
+	if err != nil {
+		r.log.Error("failed to list ledger transactions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]*entities.LedgerTransaction, 0, limit)
+	byID := make(map[string]*entities.LedgerTransaction, limit)
+	ids := make([]string, 0, limit)
+	for rows.Next() {
+		tx := &entities.LedgerTransaction{UserID: userID}
+		if err := rows.Scan(&tx.ID, &tx.Kind, &tx.Reference, &tx.CreatedAt); err != nil {
+			r.log.Error("failed to scan ledger transaction row", zap.Error(err))
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+		byID[tx.ID] = tx
+		ids = append(ids, tx.ID)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate ledger transaction rows", zap.Error(err))
+		return nil, err
+	}
+	rows.Close()
+	if len(ids) == 0 {
+		return transactions, nil
+	}
+
+	entryQuery := `SELECT transaction_id, account, system, asset_kind, asset, amount
+		FROM ledger_entries
+		WHERE transaction_id = ANY($1::uuid[])
+		ORDER BY id`
+
+	entryRows, err := r.db.Query(ctx, entryQuery, ids)
+	if err != nil {
+		r.log.Error("failed to list ledger entries", zap.Error(err))
+		return nil, err
+	}
+	defer entryRows.Close()
+
+	for entryRows.Next() {
+		var (
+			transactionID string
+			entry         entities.LedgerEntry
+		)
+		if err := entryRows.Scan(&transactionID, &entry.Account, &entry.System, &entry.AssetKind, &entry.Asset, &entry.Amount); err != nil {
+			r.log.Error("failed to scan ledger entry row", zap.Error(err))
+			return nil, err
+		}
+		if tx, ok := byID[transactionID]; ok {
+			tx.Entries = append(tx.Entries, entry)
+		}
+	}
+	if err := entryRows.Err(); err != nil {
+		r.log.Error("failed to iterate ledger entry rows", zap.Error(err))
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// transactionTime returns when the user's transaction was posted, or
+// ErrLedgerPageTokenInvalid when the ID is malformed or names no transaction
+// of the user.
+func (r *LedgerRepository) transactionTime(ctx context.Context, userID string, id string) (time.Time, error) {
+	query := `SELECT created_at FROM ledger_transactions WHERE user_id = $1 AND id = $2::uuid`
+
+	var createdAt time.Time
+	if err := r.db.QueryRow(ctx, query, userID, id).Scan(&createdAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgerrcodeInvalidTextRepresentation) {
+			return time.Time{}, exceptions.ErrLedgerPageTokenInvalid
+		}
+		r.log.Error("failed to get ledger transaction", zap.Error(err))
+		return time.Time{}, err
+	}
+	return createdAt, nil
+}
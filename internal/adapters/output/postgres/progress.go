@@ -30,26 +30,34 @@ func NewProgressRepository(db db.Querier, log *zap.Logger) *ProgressRepository {
 	}
 }
 
-func (r *ProgressRepository) Get(ctx context.Context, userID string, taskID string) (*entities.TaskProgress, error) {
-	query := `SELECT id, task_id, user_id, progress, completed, claimed, updated_at
-		FROM task_progress WHERE user_id = $1 AND task_id = $2`
+func (r *ProgressRepository) Get(ctx context.Context, userID string, taskID string, periodKey string) (*entities.TaskProgress, error) {
+	query := `SELECT id, task_id, user_id, period_key, progress, completed, claimed, claimed_tiers, completions, claimed_count, updated_at
+		FROM task_progress WHERE user_id = $1 AND task_id = $2 AND period_key = $3`
 
 	var (
-		progressID string
-		taskIDVal  string
-		userIDVal  string
-		value      int
-		completed  bool
-		claimed    bool
-		updatedAt  time.Time
+		progressID   string
+		taskIDVal    string
+		userIDVal    string
+		periodKeyVal string
+		value        int
+		completed    bool
+		claimed      bool
+		claimedTiers []int32
+		completions  int
+		claims       int
+		updatedAt    time.Time
 	)
-	err := r.db.QueryRow(ctx, query, userID, taskID).Scan(
+	err := r.db.QueryRow(ctx, query, userID, taskID, periodKey).Scan(
 		&progressID,
 		&taskIDVal,
 		&userIDVal,
+		&periodKeyVal,
 		&value,
 		&completed,
 		&claimed,
+		&claimedTiers,
+		&completions,
+		&claims,
 		&updatedAt,
 	)
 	if err != nil {
@@ -59,12 +67,15 @@ func (r *ProgressRepository) Get(ctx context.Context, userID string, taskID stri
 		r.log.Error("failed to get task progress", zap.Error(err))
 		return nil, err
 	}
-	return entities.NewTaskProgressFromData(progressID, taskIDVal, userIDVal, value, completed, claimed, updatedAt), nil
+	progress := entities.NewTaskProgressFromData(progressID, taskIDVal, userIDVal, periodKeyVal, value, completed, claimed, updatedAt)
+	progress.SetClaimedTiers(intSlice(claimedTiers))
+	progress.SetCounters(completions, claims)
+	return progress, nil
 }
 
 func (r *ProgressRepository) Create(ctx context.Context, progress *entities.TaskProgress) error {
-	query := `INSERT INTO task_progress (task_id, user_id, progress, completed, claimed, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+	query := `INSERT INTO task_progress (task_id, user_id, period_key, progress, completed, claimed, claimed_tiers, completions, claimed_count, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`
 
 	var id string
@@ -73,9 +84,13 @@ func (r *ProgressRepository) Create(ctx context.Context, progress *entities.Task
 		query,
 		progress.TaskID(),
 		progress.UserID(),
+		progress.PeriodKey(),
 		progress.Progress(),
 		progress.Completed(),
 		progress.Claimed(),
+		int32Slice(progress.ClaimedTiers()),
+		progress.Completions(),
+		progress.Claims(),
 		progress.UpdatedAt(),
 	).Scan(&id); err != nil {
 		r.log.Error("failed to create task progress", zap.Error(err))
@@ -87,8 +102,8 @@ func (r *ProgressRepository) Create(ctx context.Context, progress *entities.Task
 
 func (r *ProgressRepository) Update(ctx context.Context, progress *entities.TaskProgress) error {
 	query := `UPDATE task_progress
-		SET progress = $3, completed = $4, claimed = $5, updated_at = $6
-		WHERE user_id = $1 AND task_id = $2
+		SET progress = $4, completed = $5, claimed = $6, claimed_tiers = $7, completions = $8, claimed_count = $9, updated_at = $10
+		WHERE user_id = $1 AND task_id = $2 AND period_key = $3
 		RETURNING id`
 
 	var id string
@@ -97,9 +112,13 @@ func (r *ProgressRepository) Update(ctx context.Context, progress *entities.Task
 		query,
 		progress.UserID(),
 		progress.TaskID(),
+		progress.PeriodKey(),
 		progress.Progress(),
 		progress.Completed(),
 		progress.Claimed(),
+		int32Slice(progress.ClaimedTiers()),
+		progress.Completions(),
+		progress.Claims(),
 		progress.UpdatedAt(),
 	).Scan(&id); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -112,14 +131,28 @@ func (r *ProgressRepository) Update(ctx context.Context, progress *entities.Task
 	return nil
 }
 
-func (r *ProgressRepository) AddProgress(ctx context.Context, userID string, taskID string, amount int, target int, updatedAt time.Time) error {
-	query := `INSERT INTO task_progress (task_id, user_id, progress, completed, claimed, updated_at)
-		VALUES ($1, $2, LEAST($3::int, $4::int), $3::int >= $4::int, false, COALESCE($5, NOW()))
-		ON CONFLICT (user_id, task_id) DO UPDATE
-		SET progress = LEAST(task_progress.progress + EXCLUDED.progress, $4::int),
-			completed = task_progress.completed OR (task_progress.progress + EXCLUDED.progress >= $4::int),
+// AddProgress adds amount to the current repetition. Every full target moves a
+// repetition into completions and the remainder carries over, until repeatLimit
+// repetitions are done; then the row keeps progress at target.
+func (r *ProgressRepository) AddProgress(ctx context.Context, userID string, taskID string, periodKey string, amount int, target int, repeatLimit int, updatedAt time.Time) error {
+	query := `INSERT INTO task_progress (task_id, user_id, period_key, progress, completed, claimed, completions, updated_at)
+		VALUES (
+			$1, $2, $3,
+			CASE WHEN $4::int / $5::int >= $7::int THEN $5::int ELSE $4::int % $5::int END,
+			$4::int / $5::int >= $7::int,
+			false,
+			LEAST($4::int / $5::int, $7::int),
+			COALESCE($6, NOW())
+		)
+		ON CONFLICT (user_id, task_id, period_key) DO UPDATE
+		SET progress = CASE
+				WHEN task_progress.completions + (task_progress.progress::bigint + $4::int) / $5::int >= $7::int THEN $5::int
+				ELSE (task_progress.progress::bigint + $4::int) % $5::int
+			END,
+			completions = LEAST(task_progress.completions + (task_progress.progress::bigint + $4::int) / $5::int, $7::int),
+			completed = task_progress.completions + (task_progress.progress::bigint + $4::int) / $5::int >= $7::int,
 			updated_at = EXCLUDED.updated_at
-		WHERE task_progress.completed = false`
+		WHERE task_progress.completions < $7::int`
 
 	updatedAtValue := any(updatedAt)
 	if updatedAt.IsZero() {
@@ -131,9 +164,11 @@ func (r *ProgressRepository) AddProgress(ctx context.Context, userID string, tas
 		query,
 		taskID,
 		userID,
+		periodKey,
 		amount,
 		target,
 		updatedAtValue,
+		repeatLimit,
 	); err != nil {
 		r.log.Error("failed to add task progress", zap.Error(err))
 		return err
@@ -141,16 +176,245 @@ func (r *ProgressRepository) AddProgress(ctx context.Context, userID string, tas
 	return nil
 }
 
-func (r *ProgressRepository) Claim(ctx context.Context, userID string, taskID string) error {
+// SetProgress applies an absolute value in set or max mode. Completed rows are
+// left untouched so a lower later value cannot take the completion back.
+func (r *ProgressRepository) SetProgress(ctx context.Context, userID string, taskID string, periodKey string, value int, target int, mode entities.AggregationMode, updatedAt time.Time) error {
+	query := `INSERT INTO task_progress (task_id, user_id, period_key, progress, completed, claimed, completions, updated_at)
+		VALUES (
+			$1, $2, $3,
+			LEAST(GREATEST($4::int, 0), $5::int),
+			$4::int >= $5::int,
+			false,
+			CASE WHEN $4::int >= $5::int THEN 1 ELSE 0 END,
+			COALESCE($6, NOW())
+		)
+		ON CONFLICT (user_id, task_id, period_key) DO UPDATE
+		SET progress = LEAST(
+				CASE WHEN $7::text = 'max' THEN GREATEST(task_progress.progress, EXCLUDED.progress) ELSE EXCLUDED.progress END,
+				$5::int
+			),
+			completed = EXCLUDED.completed,
+			completions = EXCLUDED.completions,
+			updated_at = EXCLUDED.updated_at
+		WHERE task_progress.completed = false`
+
+	if _, err := r.db.Exec(
+		ctx,
+		query,
+		taskID,
+		userID,
+		periodKey,
+		value,
+		target,
+		nullableTime(updatedAt),
+		string(mode),
+	); err != nil {
+		r.log.Error("failed to set task progress", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// AddDistinct records value for the task and counts it towards progress only
+// the first time it is seen in the period. Both writes happen in one statement.
+func (r *ProgressRepository) AddDistinct(ctx context.Context, userID string, taskID string, periodKey string, value string, target int, updatedAt time.Time) error {
+	query := `WITH inserted AS (
+			INSERT INTO task_progress_values (user_id, task_id, period_key, value)
+			VALUES ($2, $1, $3, $4)
+			ON CONFLICT DO NOTHING
+			RETURNING 1
+		)
+		INSERT INTO task_progress (task_id, user_id, period_key, progress, completed, claimed, completions, updated_at)
+		SELECT $1, $2, $3, LEAST(1, $5::int), 1 >= $5::int, false, CASE WHEN 1 >= $5::int THEN 1 ELSE 0 END, COALESCE($6, NOW())
+		FROM inserted
+		ON CONFLICT (user_id, task_id, period_key) DO UPDATE
+		SET progress = LEAST(task_progress.progress + 1, $5::int),
+			completed = task_progress.progress + 1 >= $5::int,
+			completions = CASE WHEN task_progress.progress + 1 >= $5::int THEN 1 ELSE 0 END,
+			updated_at = EXCLUDED.updated_at
+		WHERE task_progress.completed = false`
+
+	if _, err := r.db.Exec(
+		ctx,
+		query,
+		taskID,
+		userID,
+		periodKey,
+		value,
+		target,
+		nullableTime(updatedAt),
+	); err != nil {
+		r.log.Error("failed to add distinct task progress", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// AddMark stores a window or sequence mark. Redelivered events are ignored.
+func (r *ProgressRepository) AddMark(ctx context.Context, userID string, taskID string, periodKey string, mark entities.ProgressMark) error {
+	query := `INSERT INTO task_progress_marks (user_id, task_id, period_key, event_id, step, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.Exec(ctx, query, userID, taskID, periodKey, mark.EventID, mark.Step, mark.OccurredAt); err != nil {
+		r.log.Error("failed to add progress mark", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *ProgressRepository) ListMarks(ctx context.Context, userID string, taskID string, periodKey string, from time.Time, to time.Time) ([]entities.ProgressMark, error) {
+	query := `SELECT step, event_id, occurred_at
+		FROM task_progress_marks
+		WHERE user_id = $1 AND task_id = $2 AND period_key = $3
+			AND ($4::timestamptz IS NULL OR occurred_at >= $4)
+			AND ($5::timestamptz IS NULL OR occurred_at <= $5)
+		ORDER BY occurred_at, step`
+
+	rows, err := r.db.Query(ctx, query, userID, taskID, periodKey, nullableTime(from), nullableTime(to))
+	if err != nil {
+		r.log.Error("failed to list progress marks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	marks := make([]entities.ProgressMark, 0)
+	for rows.Next() {
+		var mark entities.ProgressMark
+		if err := rows.Scan(&mark.Step, &mark.EventID, &mark.OccurredAt); err != nil {
+			r.log.Error("failed to scan progress mark", zap.Error(err))
+			return nil, err
+		}
+		marks = append(marks, mark)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate progress marks", zap.Error(err))
+		return nil, err
+	}
+	return marks, nil
+}
+
+// ClearMarks drops the marks of a completed task; they can no longer change its progress.
+func (r *ProgressRepository) ClearMarks(ctx context.Context, userID string, taskID string, periodKey string) error {
+	query := `DELETE FROM task_progress_marks WHERE user_id = $1 AND task_id = $2 AND period_key = $3`
+
+	if _, err := r.db.Exec(ctx, query, userID, taskID, periodKey); err != nil {
+		r.log.Error("failed to clear progress marks", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *ProgressRepository) DeleteMarksBefore(ctx context.Context, userID string, taskID string, periodKey string, before time.Time) error {
+	query := `DELETE FROM task_progress_marks
+		WHERE user_id = $1 AND task_id = $2 AND period_key = $3 AND occurred_at < $4`
+
+	if _, err := r.db.Exec(ctx, query, userID, taskID, periodKey, before); err != nil {
+		r.log.Error("failed to delete progress marks", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *ProgressRepository) DeleteMarks(ctx context.Context, userID string, taskID string, periodKey string, marks []entities.ProgressMark) error {
+	if len(marks) == 0 {
+		return nil
+	}
+	query := `DELETE FROM task_progress_marks
+		WHERE user_id = $1 AND task_id = $2 AND period_key = $3
+			AND (event_id, step) IN (SELECT * FROM unnest($4::text[], $5::int[]))`
+
+	eventIDs := make([]string, 0, len(marks))
+	steps := make([]int32, 0, len(marks))
+	for _, mark := range marks {
+		eventIDs = append(eventIDs, mark.EventID)
+		steps = append(steps, int32(mark.Step))
+	}
+	if _, err := r.db.Exec(ctx, query, userID, taskID, periodKey, eventIDs, steps); err != nil {
+		r.log.Error("failed to delete progress marks", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DeletePastPeriodMarks relies on events being filed under the period they
+// arrive in, so a period that has ended gets no new marks.
+func (r *ProgressRepository) DeletePastPeriodMarks(ctx context.Context, userID string, taskID string, periodKey string) error {
+	query := `DELETE FROM task_progress_marks
+		WHERE user_id = $1 AND task_id = $2 AND period_key <> $3`
+
+	if _, err := r.db.Exec(ctx, query, userID, taskID, periodKey); err != nil {
+		r.log.Error("failed to delete past period marks", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetStreak returns the user's streak for the task, locking the row for the
+// rest of the transaction so concurrent events extend it one at a time.
+func (r *ProgressRepository) GetStreak(ctx context.Context, userID string, taskID string) (*entities.Streak, error) {
+	query := `SELECT current_streak, longest_streak, last_day, updated_at
+		FROM user_streaks
+		WHERE user_id = $1 AND task_id = $2
+		FOR UPDATE`
+
+	var (
+		current   int
+		longest   int
+		lastDay   string
+		updatedAt time.Time
+	)
+	if err := r.db.QueryRow(ctx, query, userID, taskID).Scan(&current, &longest, &lastDay, &updatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrProgressNotFound
+		}
+		r.log.Error("failed to get streak", zap.Error(err))
+		return nil, err
+	}
+	return entities.NewStreakFromData(userID, taskID, current, longest, lastDay, updatedAt), nil
+}
+
+func (r *ProgressRepository) SaveStreak(ctx context.Context, streak *entities.Streak) error {
+	query := `INSERT INTO user_streaks (user_id, task_id, current_streak, longest_streak, last_day, updated_at)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, NOW()))
+		ON CONFLICT (user_id, task_id) DO UPDATE
+		SET current_streak = EXCLUDED.current_streak,
+			longest_streak = EXCLUDED.longest_streak,
+			last_day = EXCLUDED.last_day,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.Exec(
+		ctx,
+		query,
+		streak.UserID(),
+		streak.TaskID(),
+		streak.Current(),
+		streak.Longest(),
+		streak.LastDay(),
+		nullableTime(streak.UpdatedAt()),
+	); err != nil {
+		r.log.Error("failed to save streak", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Claim marks a single tier as claimed. The row is flagged claimed once every
+// one of tierCount tiers has been claimed.
+func (r *ProgressRepository) Claim(ctx context.Context, userID string, taskID string, periodKey string, tier int, tierTarget int, tierCount int) error {
 	query := `UPDATE task_progress
-		SET claimed = true, updated_at = NOW()
-		WHERE user_id = $1 AND task_id = $2 AND claimed = false AND completed = true
+		SET claimed_tiers = array_append(claimed_tiers, $4::int),
+			claimed = cardinality(claimed_tiers) + 1 >= $6::int,
+			claimed_count = CASE WHEN cardinality(claimed_tiers) + 1 >= $6::int THEN 1 ELSE 0 END,
+			updated_at = NOW()
+		WHERE user_id = $1 AND task_id = $2 AND period_key = $3
+			AND claimed = false AND progress >= $5::int AND NOT ($4::int = ANY(claimed_tiers))
 		RETURNING id`
 
 	var id string
-	if err := r.db.QueryRow(ctx, query, userID, taskID).Scan(&id); err != nil {
+	if err := r.db.QueryRow(ctx, query, userID, taskID, periodKey, tier, tierTarget, tierCount).Scan(&id); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return r.claimStateError(ctx, userID, taskID)
+			return r.claimStateError(ctx, userID, taskID, periodKey, tier, tierTarget)
 		}
 		r.log.Error("failed to claim task reward", zap.Error(err))
 		return err
@@ -158,11 +422,137 @@ func (r *ProgressRepository) Claim(ctx context.Context, userID string, taskID st
 	return nil
 }
 
-func (r *ProgressRepository) claimStateError(ctx context.Context, userID string, taskID string) error {
-	query := `SELECT completed, claimed FROM task_progress WHERE user_id = $1 AND task_id = $2`
+// ClaimRepetition claims the oldest completed but unclaimed repetition of a
+// repeatable task. The row is flagged claimed once repeatLimit repetitions are claimed.
+func (r *ProgressRepository) ClaimRepetition(ctx context.Context, userID string, taskID string, periodKey string, repeatLimit int) error {
+	query := `UPDATE task_progress
+		SET claimed_count = claimed_count + 1,
+			claimed = claimed_count + 1 >= $4::int,
+			updated_at = NOW()
+		WHERE user_id = $1 AND task_id = $2 AND period_key = $3
+			AND claimed = false AND claimed_count < completions
+		RETURNING id`
+
+	var id string
+	if err := r.db.QueryRow(ctx, query, userID, taskID, periodKey, repeatLimit).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.repetitionStateError(ctx, userID, taskID, periodKey, repeatLimit)
+		}
+		r.log.Error("failed to claim task repetition", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *ProgressRepository) ClaimedTaskIDs(ctx context.Context, userID string, taskIDs []string) ([]string, error) {
+	query := `SELECT task_id::text FROM task_progress
+		WHERE user_id = $1 AND task_id = ANY($2::uuid[]) AND claimed = true
+		UNION
+		SELECT task_id::text FROM room_contributions
+		WHERE user_id = $1 AND task_id = ANY($2::uuid[]) AND claimed = true`
+
+	rows, err := r.db.Query(ctx, query, userID, taskIDs)
+	if err != nil {
+		r.log.Error("failed to list claimed tasks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	claimed := make([]string, 0, len(taskIDs))
+	for rows.Next() {
+		var taskID string
+		if err := rows.Scan(&taskID); err != nil {
+			r.log.Error("failed to scan claimed task row", zap.Error(err))
+			return nil, err
+		}
+		claimed = append(claimed, taskID)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate claimed task rows", zap.Error(err))
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// ListClaimable matches the predicate of idx_task_progress_completed_not_claimed.
+func (r *ProgressRepository) ListClaimable(ctx context.Context, userID string) ([]*entities.TaskProgress, error) {
+	query := `SELECT p.id, p.task_id, p.user_id, p.period_key, p.progress, p.completed, p.claimed, p.claimed_tiers, p.completions, p.claimed_count, p.updated_at
+		FROM task_progress p
+		JOIN tasks t ON t.id = p.task_id
+		WHERE p.user_id = $1 AND p.claimed = false
+			AND (
+				p.completed = true
+				OR p.completions > p.claimed_count
+				OR EXISTS (
+					SELECT 1
+					FROM jsonb_array_elements(CASE WHEN jsonb_typeof(t.tiers) = 'array' THEN t.tiers END)
+						WITH ORDINALITY AS tier(value, number)
+					WHERE (tier.value->>'target')::int <= p.progress
+						AND NOT (tier.number::int = ANY(p.claimed_tiers))
+				)
+			)
+		ORDER BY p.updated_at, p.task_id`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("failed to list claimable progress", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	claimable := make([]*entities.TaskProgress, 0)
+	for rows.Next() {
+		var (
+			progressID   string
+			taskID       string
+			userIDVal    string
+			periodKey    string
+			value        int
+			completed    bool
+			claimed      bool
+			claimedTiers []int32
+			completions  int
+			claims       int
+			updatedAt    time.Time
+		)
+		if err := rows.Scan(
+			&progressID,
+			&taskID,
+			&userIDVal,
+			&periodKey,
+			&value,
+			&completed,
+			&claimed,
+			&claimedTiers,
+			&completions,
+			&claims,
+			&updatedAt,
+		); err != nil {
+			r.log.Error("failed to scan claimable progress row", zap.Error(err))
+			return nil, err
+		}
+		progress := entities.NewTaskProgressFromData(progressID, taskID, userIDVal, periodKey, value, completed, claimed, updatedAt)
+		progress.SetClaimedTiers(intSlice(claimedTiers))
+		progress.SetCounters(completions, claims)
+		claimable = append(claimable, progress)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate claimable progress rows", zap.Error(err))
+		return nil, err
+	}
+
+	return claimable, nil
+}
+
+func (r *ProgressRepository) claimStateError(ctx context.Context, userID string, taskID string, periodKey string, tier int, tierTarget int) error {
+	query := `SELECT progress >= $4::int, claimed OR $5::int = ANY(claimed_tiers)
+		FROM task_progress WHERE user_id = $1 AND task_id = $2 AND period_key = $3`
 
 	var completed, claimed bool
-	if err := r.db.QueryRow(ctx, query, userID, taskID).Scan(&completed, &claimed); err != nil {
+	if err := r.db.QueryRow(ctx, query, userID, taskID, periodKey, tierTarget, tier).Scan(&completed, &claimed); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return exceptions.ErrProgressNotFound
 		}
@@ -177,3 +567,36 @@ func (r *ProgressRepository) claimStateError(ctx context.Context, userID string,
 	}
 	return errors.New("claim reward failed")
 }
+
+func (r *ProgressRepository) repetitionStateError(ctx context.Context, userID string, taskID string, periodKey string, repeatLimit int) error {
+	query := `SELECT claimed_count >= $4::int FROM task_progress WHERE user_id = $1 AND task_id = $2 AND period_key = $3`
+
+	var claimed bool
+	if err := r.db.QueryRow(ctx, query, userID, taskID, periodKey, repeatLimit).Scan(&claimed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return exceptions.ErrProgressNotFound
+		}
+		r.log.Error("failed to check repetition claim state", zap.Error(err))
+		return err
+	}
+	if claimed {
+		return exceptions.ErrRewardAlreadyClaimed
+	}
+	return exceptions.ErrTaskNotCompleted
+}
+
+func intSlice(values []int32) []int {
+	result := make([]int, 0, len(values))
+	for _, value := range values {
+		result = append(result, int(value))
+	}
+	return result
+}
+
+func int32Slice(values []int) []int32 {
+	result := make([]int32, 0, len(values))
+	for _, value := range values {
+		result = append(result, int32(value))
+	}
+	return result
+}
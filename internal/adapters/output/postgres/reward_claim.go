@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type RewardClaimRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewRewardClaimRepository(db db.Querier, log *zap.Logger) *RewardClaimRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &RewardClaimRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *RewardClaimRepository) Get(ctx context.Context, userID string, idempotencyKey string) (*entities.RewardClaim, error) {
+	query := `SELECT task_id, period_key, tier, room_id, reward, grant_id::text, claimed_at
+		FROM reward_claims
+		WHERE user_id = $1 AND idempotency_key = $2`
+
+	var (
+		claim   = &entities.RewardClaim{IdempotencyKey: idempotencyKey, UserID: userID}
+		roomID  sql.NullString
+		grantID sql.NullString
+	)
+	if err := r.db.QueryRow(ctx, query, userID, idempotencyKey).Scan(
+		&claim.TaskID,
+		&claim.PeriodKey,
+		&claim.Tier,
+		&roomID,
+		&claim.Reward,
+		&grantID,
+		&claim.ClaimedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.log.Error("failed to get reward claim", zap.Error(err))
+		return nil, err
+	}
+	claim.RoomID = roomID.String
+	claim.GrantID = grantID.String
+	return claim, nil
+}
+
+func (r *RewardClaimRepository) Save(ctx context.Context, claim *entities.RewardClaim) error {
+	query := `INSERT INTO reward_claims (user_id, idempotency_key, task_id, period_key, tier, room_id, reward, grant_id, claimed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, idempotency_key) DO NOTHING`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		claim.UserID,
+		claim.IdempotencyKey,
+		claim.TaskID,
+		claim.PeriodKey,
+		claim.Tier,
+		nullableString(claim.RoomID),
+		nullableJSON(claim.Reward),
+		nullableString(claim.GrantID),
+		claim.ClaimedAt,
+	)
+	if err != nil {
+		r.log.Error("failed to save reward claim", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return exceptions.ErrIdempotencyKeyExists
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/infrastructure/db"
+
+	"go.uber.org/zap"
+)
+
+type RewardOutboxRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewRewardOutboxRepository(db db.Querier, log *zap.Logger) *RewardOutboxRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &RewardOutboxRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *RewardOutboxRepository) Enqueue(ctx context.Context, grant *entities.RewardGrant) error {
+	query := `INSERT INTO reward_outbox (user_id, source, source_id, task_id, period_key, tier, reward, status, attempts, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, next_attempt_at, created_at`
+
+	if err := r.db.QueryRow(
+		ctx,
+		query,
+		grant.UserID,
+		grant.Source,
+		grant.SourceID,
+		nullableString(grant.TaskID),
+		grant.PeriodKey,
+		grant.Tier,
+		grant.Reward,
+		grant.Status,
+		grant.Attempts,
+		nullableTime(grant.DeliveredAt),
+	).Scan(&grant.ID, &grant.NextAttemptAt, &grant.CreatedAt); err != nil {
+		r.log.Error("failed to enqueue reward grant", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *RewardOutboxRepository) Lease(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*entities.RewardGrant, error) {
+	query := `UPDATE reward_outbox
+		SET next_attempt_at = $3
+		WHERE id IN (
+			SELECT id FROM reward_outbox
+			WHERE status = $1 AND next_attempt_at <= $2
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, source, source_id, COALESCE(task_id::text, ''), period_key, tier, reward, status, attempts, COALESCE(last_error, ''), created_at`
+
+	rows, err := r.db.Query(ctx, query, entities.RewardDeliveryPending, now, leaseUntil, limit)
+	if err != nil {
+		r.log.Error("failed to lease reward grants", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make([]*entities.RewardGrant, 0, limit)
+	for rows.Next() {
+		grant := &entities.RewardGrant{NextAttemptAt: leaseUntil}
+		if err := rows.Scan(
+			&grant.ID,
+			&grant.UserID,
+			&grant.Source,
+			&grant.SourceID,
+			&grant.TaskID,
+			&grant.PeriodKey,
+			&grant.Tier,
+			&grant.Reward,
+			&grant.Status,
+			&grant.Attempts,
+			&grant.LastError,
+			&grant.CreatedAt,
+		); err != nil {
+			r.log.Error("failed to scan reward grant row", zap.Error(err))
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate reward grant rows", zap.Error(err))
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (r *RewardOutboxRepository) SaveDelivery(ctx context.Context, grant *entities.RewardGrant) error {
+	query := `UPDATE reward_outbox
+		SET status = $2,
+			attempts = $3,
+			next_attempt_at = $4,
+			last_error = $5,
+			delivered_at = $6
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(
+		ctx,
+		query,
+		grant.ID,
+		grant.Status,
+		grant.Attempts,
+		grant.NextAttemptAt,
+		nullableString(grant.LastError),
+		nullableTime(grant.DeliveredAt),
+	); err != nil {
+		r.log.Error("failed to save reward delivery", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *RewardOutboxRepository) CountPending(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM reward_outbox WHERE status = $1`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, entities.RewardDeliveryPending).Scan(&count); err != nil {
+		r.log.Error("failed to count pending reward grants", zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
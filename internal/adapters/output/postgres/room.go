@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type RoomRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewRoomRepository(db db.Querier, log *zap.Logger) *RoomRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &RoomRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// AddProgress credits the member only with the part of the amount that still
+// counted towards the target, so contributions add up to the room counter.
+func (r *RoomRepository) AddProgress(ctx context.Context, taskID string, roomID string, periodKey string, userID string, amount int, target int, updatedAt time.Time) (bool, error) {
+	ensureQuery := `INSERT INTO room_progress (task_id, room_id, period_key, progress, target, updated_at)
+		VALUES ($1, $2, $3, 0, $4, COALESCE($5, NOW()))
+		ON CONFLICT (task_id, room_id, period_key) DO NOTHING`
+
+	if _, err := r.db.Exec(ctx, ensureQuery, taskID, roomID, periodKey, target, nullableTime(updatedAt)); err != nil {
+		r.log.Error("failed to create room progress", zap.Error(err))
+		return false, err
+	}
+
+	lockQuery := `SELECT progress, completed FROM room_progress
+		WHERE task_id = $1 AND room_id = $2 AND period_key = $3
+		FOR UPDATE`
+
+	var (
+		progress  int
+		completed bool
+	)
+	if err := r.db.QueryRow(ctx, lockQuery, taskID, roomID, periodKey).Scan(&progress, &completed); err != nil {
+		r.log.Error("failed to lock room progress", zap.Error(err))
+		return false, err
+	}
+	applied := min(amount, target-progress)
+	if completed || applied <= 0 {
+		return false, nil
+	}
+	completed = progress+applied >= target
+
+	addQuery := `UPDATE room_progress
+		SET progress = progress + $4,
+			target = $5,
+			completed = $6,
+			completed_at = CASE WHEN $6 THEN COALESCE($7, NOW()) END,
+			updated_at = COALESCE($7, NOW())
+		WHERE task_id = $1 AND room_id = $2 AND period_key = $3`
+
+	if _, err := r.db.Exec(ctx, addQuery, taskID, roomID, periodKey, applied, target, completed, nullableTime(updatedAt)); err != nil {
+		r.log.Error("failed to add room progress", zap.Error(err))
+		return false, err
+	}
+
+	contributeQuery := `INSERT INTO room_contributions (task_id, room_id, period_key, user_id, amount, updated_at)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, NOW()))
+		ON CONFLICT (task_id, room_id, period_key, user_id) DO UPDATE
+		SET amount = room_contributions.amount + EXCLUDED.amount,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.Exec(ctx, contributeQuery, taskID, roomID, periodKey, userID, applied, nullableTime(updatedAt)); err != nil {
+		r.log.Error("failed to add room contribution", zap.Error(err))
+		return false, err
+	}
+	return completed, nil
+}
+
+func (r *RoomRepository) Get(ctx context.Context, taskID string, roomID string, periodKey string) (*entities.RoomProgress, error) {
+	progressQuery := `SELECT progress, target, completed, completed_at, updated_at
+		FROM room_progress
+		WHERE task_id = $1 AND room_id = $2 AND period_key = $3`
+
+	progress := &entities.RoomProgress{
+		TaskID:    taskID,
+		RoomID:    roomID,
+		PeriodKey: periodKey,
+	}
+	var completedAt *time.Time
+	if err := r.db.QueryRow(ctx, progressQuery, taskID, roomID, periodKey).Scan(
+		&progress.Progress,
+		&progress.Target,
+		&progress.Completed,
+		&completedAt,
+		&progress.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrProgressNotFound
+		}
+		r.log.Error("failed to get room progress", zap.Error(err))
+		return nil, err
+	}
+	if completedAt != nil {
+		progress.CompletedAt = *completedAt
+	}
+
+	contributionsQuery := `SELECT user_id, amount, claimed, updated_at
+		FROM room_contributions
+		WHERE task_id = $1 AND room_id = $2 AND period_key = $3
+		ORDER BY amount DESC, user_id`
+
+	rows, err := r.db.Query(ctx, contributionsQuery, taskID, roomID, periodKey)
+	if err != nil {
+		r.log.Error("failed to list room contributions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var contribution entities.RoomContribution
+		if err := rows.Scan(&contribution.UserID, &contribution.Amount, &contribution.Claimed, &contribution.UpdatedAt); err != nil {
+			r.log.Error("failed to scan room contribution", zap.Error(err))
+			return nil, err
+		}
+		progress.Contributions = append(progress.Contributions, contribution)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate room contributions", zap.Error(err))
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (r *RoomRepository) FindUserRoom(ctx context.Context, userID string, taskID string, periodKey string) (string, error) {
+	query := `SELECT c.room_id
+		FROM room_contributions c
+		JOIN room_progress p USING (task_id, room_id, period_key)
+		WHERE c.user_id = $1 AND c.task_id = $2 AND c.period_key = $3
+		ORDER BY (p.completed AND NOT c.claimed) DESC, c.updated_at DESC
+		LIMIT 1`
+
+	var roomID string
+	if err := r.db.QueryRow(ctx, query, userID, taskID, periodKey).Scan(&roomID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", exceptions.ErrProgressNotFound
+		}
+		r.log.Error("failed to find user room", zap.Error(err))
+		return "", err
+	}
+	return roomID, nil
+}
+
+func (r *RoomRepository) Claim(ctx context.Context, userID string, taskID string, roomID string, periodKey string) error {
+	query := `UPDATE room_contributions c
+		SET claimed = true,
+			claimed_at = NOW()
+		FROM room_progress p
+		WHERE c.user_id = $1 AND c.task_id = $2 AND c.room_id = $3 AND c.period_key = $4
+			AND NOT c.claimed
+			AND p.task_id = c.task_id AND p.room_id = c.room_id AND p.period_key = c.period_key
+			AND p.completed
+		RETURNING c.user_id`
+
+	var id string
+	if err := r.db.QueryRow(ctx, query, userID, taskID, roomID, periodKey).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.claimStateError(ctx, userID, taskID, roomID, periodKey)
+		}
+		r.log.Error("failed to claim room reward", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *RoomRepository) claimStateError(ctx context.Context, userID string, taskID string, roomID string, periodKey string) error {
+	progress, err := r.Get(ctx, taskID, roomID, periodKey)
+	if err != nil {
+		return err
+	}
+	contribution, ok := progress.Contribution(userID)
+	switch {
+	case !ok:
+		return exceptions.ErrProgressNotFound
+	case contribution.Claimed:
+		return exceptions.ErrRewardAlreadyClaimed
+	default:
+		return exceptions.ErrTaskNotCompleted
+	}
+}
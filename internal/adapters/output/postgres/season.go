@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const seasonColumns = `id, name, starts_at, ends_at, levels, created_at`
+
+type SeasonRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewSeasonRepository(db db.Querier, log *zap.Logger) *SeasonRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &SeasonRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *SeasonRepository) GetByID(ctx context.Context, id string) (*entities.Season, error) {
+	query := `SELECT ` + seasonColumns + ` FROM seasons WHERE id = $1`
+
+	season, err := scanSeason(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrSeasonNotFound
+		}
+		r.log.Error("failed to get season", zap.Error(err))
+		return nil, err
+	}
+	return season, nil
+}
+
+func (r *SeasonRepository) GetActive(ctx context.Context, at time.Time) (*entities.Season, error) {
+	query := `SELECT ` + seasonColumns + ` FROM seasons
+		WHERE starts_at <= $1 AND ends_at > $1
+		ORDER BY starts_at DESC
+		LIMIT 1`
+
+	season, err := scanSeason(r.db.QueryRow(ctx, query, at))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrSeasonNotFound
+		}
+		r.log.Error("failed to get active season", zap.Error(err))
+		return nil, err
+	}
+	return season, nil
+}
+
+func (r *SeasonRepository) List(ctx context.Context) ([]*entities.Season, error) {
+	query := `SELECT ` + seasonColumns + ` FROM seasons ORDER BY starts_at, id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to list seasons", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var seasons []*entities.Season
+	for rows.Next() {
+		season, err := scanSeason(rows)
+		if err != nil {
+			r.log.Error("failed to scan season", zap.Error(err))
+			return nil, err
+		}
+		seasons = append(seasons, season)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate seasons", zap.Error(err))
+		return nil, err
+	}
+	return seasons, nil
+}
+
+func (r *SeasonRepository) Overlaps(ctx context.Context, startsAt time.Time, endsAt time.Time) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM seasons WHERE starts_at < $2 AND ends_at > $1)`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, startsAt, endsAt).Scan(&exists); err != nil {
+		r.log.Error("failed to check season overlap", zap.Error(err))
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *SeasonRepository) Create(ctx context.Context, season *entities.Season) error {
+	query := `INSERT INTO seasons (name, starts_at, ends_at, levels)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	levels, err := json.Marshal(season.Levels())
+	if err != nil {
+		r.log.Error("failed to marshal season levels", zap.Error(err))
+		return err
+	}
+
+	var (
+		id        string
+		createdAt time.Time
+	)
+	if err := r.db.QueryRow(ctx, query, season.Name(), season.StartsAt(), season.EndsAt(), levels).Scan(&id, &createdAt); err != nil {
+		r.log.Error("failed to create season", zap.Error(err))
+		return err
+	}
+	season.SetID(id)
+	season.SetCreatedAt(createdAt)
+	return nil
+}
+
+func (r *SeasonRepository) GetProgress(ctx context.Context, userID string, seasonID string) (*entities.SeasonProgress, error) {
+	query := `SELECT xp, premium, claimed_free, claimed_premium, updated_at
+		FROM season_progress
+		WHERE user_id = $1 AND season_id = $2`
+
+	var (
+		xp             int
+		premium        bool
+		claimedFree    []int32
+		claimedPremium []int32
+		updatedAt      time.Time
+	)
+	if err := r.db.QueryRow(ctx, query, userID, seasonID).Scan(&xp, &premium, &claimedFree, &claimedPremium, &updatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrProgressNotFound
+		}
+		r.log.Error("failed to get season progress", zap.Error(err))
+		return nil, err
+	}
+	return entities.NewSeasonProgressFromData(userID, seasonID, xp, premium, intSlice(claimedFree), intSlice(claimedPremium), updatedAt), nil
+}
+
+func (r *SeasonRepository) AddXP(ctx context.Context, userID string, seasonID string, xp int, updatedAt time.Time) error {
+	query := `INSERT INTO season_progress (user_id, season_id, xp, updated_at)
+		VALUES ($1, $2, $3, COALESCE($4, NOW()))
+		ON CONFLICT (user_id, season_id) DO UPDATE
+		SET xp = season_progress.xp + EXCLUDED.xp,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.Exec(ctx, query, userID, seasonID, xp, nullableTime(updatedAt)); err != nil {
+		r.log.Error("failed to add season xp", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *SeasonRepository) SetPremium(ctx context.Context, userID string, seasonID string, premium bool, updatedAt time.Time) error {
+	query := `INSERT INTO season_progress (user_id, season_id, premium, updated_at)
+		VALUES ($1, $2, $3, COALESCE($4, NOW()))
+		ON CONFLICT (user_id, season_id) DO UPDATE
+		SET premium = EXCLUDED.premium,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.Exec(ctx, query, userID, seasonID, premium, nullableTime(updatedAt)); err != nil {
+		r.log.Error("failed to set season premium", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ClaimLevel records the level reward on the track as claimed when the user
+// has reached requiredXP and, for the premium track, owns the pass.
+func (r *SeasonRepository) ClaimLevel(ctx context.Context, userID string, seasonID string, level int, track entities.SeasonTrack, requiredXP int) error {
+	query := `UPDATE season_progress
+		SET claimed_free = CASE WHEN $4::text = 'free' THEN array_append(claimed_free, $3::int) ELSE claimed_free END,
+			claimed_premium = CASE WHEN $4::text = 'premium' THEN array_append(claimed_premium, $3::int) ELSE claimed_premium END,
+			updated_at = NOW()
+		WHERE user_id = $1 AND season_id = $2 AND xp >= $5::int
+			AND ($4::text = 'free' OR premium)
+			AND NOT ($3::int = ANY(CASE WHEN $4::text = 'premium' THEN claimed_premium ELSE claimed_free END))
+		RETURNING user_id`
+
+	var id string
+	if err := r.db.QueryRow(ctx, query, userID, seasonID, level, string(track), requiredXP).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.claimLevelStateError(ctx, userID, seasonID, level, track, requiredXP)
+		}
+		r.log.Error("failed to claim season level", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *SeasonRepository) claimLevelStateError(ctx context.Context, userID string, seasonID string, level int, track entities.SeasonTrack, requiredXP int) error {
+	progress, err := r.GetProgress(ctx, userID, seasonID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrProgressNotFound) {
+			return exceptions.ErrSeasonLevelNotReached
+		}
+		return err
+	}
+	switch {
+	case progress.IsLevelClaimed(level, track):
+		return exceptions.ErrRewardAlreadyClaimed
+	case track == entities.SeasonTrackPremium && !progress.Premium():
+		return exceptions.ErrSeasonPremiumRequired
+	case progress.XP() < requiredXP:
+		return exceptions.ErrSeasonLevelNotReached
+	default:
+		return exceptions.ErrProgressNotFound
+	}
+}
+
+func scanSeason(row rowScanner) (*entities.Season, error) {
+	var (
+		id         string
+		name       string
+		startsAt   time.Time
+		endsAt     time.Time
+		levelsJSON []byte
+		createdAt  time.Time
+	)
+	if err := row.Scan(&id, &name, &startsAt, &endsAt, &levelsJSON, &createdAt); err != nil {
+		return nil, err
+	}
+
+	var levels []entities.SeasonLevel
+	if err := json.Unmarshal(levelsJSON, &levels); err != nil {
+		return nil, err
+	}
+	return entities.NewSeason(id, name, startsAt, endsAt, levels, createdAt), nil
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type SessionRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewSessionRepository(db db.Querier, log *zap.Logger) *SessionRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &SessionRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *SessionRepository) Start(ctx context.Context, session *entities.Session) error {
+	query := `INSERT INTO user_sessions (user_id, session_key, started_at, attributes)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, session_key) DO UPDATE
+		SET started_at = EXCLUDED.started_at,
+			attributes = EXCLUDED.attributes`
+
+	var attributes any
+	if len(session.Attributes) > 0 {
+		data, err := json.Marshal(session.Attributes)
+		if err != nil {
+			r.log.Error("failed to marshal session attributes", zap.Error(err))
+			return err
+		}
+		attributes = data
+	}
+
+	if _, err := r.db.Exec(ctx, query, session.UserID, session.Key, session.StartedAt, attributes); err != nil {
+		r.log.Error("failed to start session", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *SessionRepository) Stop(ctx context.Context, userID string, sessionKey string) (*entities.Session, error) {
+	query := `DELETE FROM user_sessions
+		WHERE user_id = $1 AND session_key = $2
+		RETURNING started_at, attributes`
+
+	var (
+		startedAt      time.Time
+		attributesJSON []byte
+	)
+	if err := r.db.QueryRow(ctx, query, userID, sessionKey).Scan(&startedAt, &attributesJSON); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrSessionNotFound
+		}
+		r.log.Error("failed to stop session", zap.Error(err))
+		return nil, err
+	}
+
+	session := &entities.Session{
+		UserID:    userID,
+		Key:       sessionKey,
+		StartedAt: startedAt,
+	}
+	if len(attributesJSON) > 0 {
+		if err := json.Unmarshal(attributesJSON, &session.Attributes); err != nil {
+			r.log.Error("failed to unmarshal session attributes", zap.Error(err))
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) StopStartedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.Session, error) {
+	query := `DELETE FROM user_sessions
+		WHERE (user_id, session_key) IN (
+			SELECT user_id, session_key FROM user_sessions
+			WHERE started_at < $1
+			ORDER BY started_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING user_id, session_key, started_at, attributes`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		r.log.Error("failed to stop expired sessions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*entities.Session, 0, limit)
+	for rows.Next() {
+		var (
+			session        entities.Session
+			attributesJSON []byte
+		)
+		if err := rows.Scan(&session.UserID, &session.Key, &session.StartedAt, &attributesJSON); err != nil {
+			r.log.Error("failed to scan session row", zap.Error(err))
+			return nil, err
+		}
+		if len(attributesJSON) > 0 {
+			if err := json.Unmarshal(attributesJSON, &session.Attributes); err != nil {
+				r.log.Error("failed to unmarshal session attributes", zap.Error(err))
+				return nil, err
+			}
+		}
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate session rows", zap.Error(err))
+		return nil, err
+	}
+	return sessions, nil
+}
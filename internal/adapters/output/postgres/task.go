@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"task-manager/internal/core/domain/entities"
 	"task-manager/internal/core/domain/exceptions"
@@ -13,11 +14,27 @@ import (
 	"go.uber.org/zap"
 )
 
+const taskColumns = `id, title, description, type, target, reward, tiers, repeat_limit, rule,
+	aggregation_mode, aggregation_attribute, aggregation_window_seconds, aggregation_steps, season_xp, streak, scope, leaderboard,
+	is_active, reset_period, starts_at, ends_at, created_at,
+	COALESCE((SELECT array_agg(tp.prerequisite_id::text ORDER BY tp.prerequisite_id)
+		FROM task_prerequisites tp WHERE tp.task_id = tasks.id), '{}'),
+	COALESCE((SELECT array_agg(qc.child_id::text ORDER BY qc.child_id)
+		FROM task_quest_children qc WHERE qc.quest_id = tasks.id), '{}'),
+	COALESCE((SELECT array_agg(qp.quest_id::text ORDER BY qp.quest_id)
+		FROM task_quest_children qp WHERE qp.child_id = tasks.id), '{}'),
+	COALESCE((SELECT array_agg(st.id::text ORDER BY st.id)
+		FROM tasks st WHERE st.streak->'task_ids' ? tasks.id::text), '{}')`
+
 type TaskRepository struct {
 	db  db.Querier
 	log *zap.Logger
 }
 
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 func NewTaskRepository(db db.Querier, log *zap.Logger) *TaskRepository {
 	if db == nil {
 		log.Fatal("database querier is nil")
@@ -32,9 +49,277 @@ func NewTaskRepository(db db.Querier, log *zap.Logger) *TaskRepository {
 }
 
 func (r *TaskRepository) GetByID(ctx context.Context, id string) (*entities.Task, error) {
-	query := `SELECT id, title, description, type, target, reward, is_active, created_at
+	query := `SELECT ` + taskColumns + `
 		FROM tasks WHERE id = $1`
 
+	task, err := scanTask(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrTaskNotFound
+		}
+		return nil, err
+	}
+	r.checkRewards(task)
+	return task, nil
+}
+
+func (r *TaskRepository) ListActive(ctx context.Context, at time.Time) ([]*entities.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	FROM tasks
+	WHERE is_active = true
+		AND (starts_at IS NULL OR starts_at <= $1)
+		AND (ends_at IS NULL OR ends_at > $1)`
+
+	return r.list(ctx, query, at)
+}
+
+func (r *TaskRepository) ListActiveByEventType(ctx context.Context, eventType entities.TaskEventType, at time.Time) ([]*entities.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	FROM tasks
+	WHERE is_active = true
+		AND rule -> 'event_types' ? $2
+		AND (starts_at IS NULL OR starts_at <= $1)
+		AND (ends_at IS NULL OR ends_at > $1)`
+
+	return r.list(ctx, query, at, string(eventType))
+}
+
+func (r *TaskRepository) ListAll(ctx context.Context) ([]*entities.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	FROM tasks ORDER BY created_at`
+
+	return r.list(ctx, query)
+}
+
+func (r *TaskRepository) Create(ctx context.Context, task *entities.Task) error {
+	query := `INSERT INTO tasks (title, description, type, target, reward, tiers, repeat_limit, rule,
+			aggregation_mode, aggregation_attribute, aggregation_window_seconds, aggregation_steps, season_xp, streak,
+			scope, leaderboard, is_active, reset_period, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING id, created_at`
+
+	tiers, err := marshalTiers(task)
+	if err != nil {
+		r.log.Error("failed to marshal task tiers", zap.Error(err))
+		return err
+	}
+	rule, err := marshalRule(task)
+	if err != nil {
+		r.log.Error("failed to marshal task rule", zap.Error(err))
+		return err
+	}
+	steps, err := marshalSteps(task)
+	if err != nil {
+		r.log.Error("failed to marshal task aggregation steps", zap.Error(err))
+		return err
+	}
+	streak, err := marshalStreak(task)
+	if err != nil {
+		r.log.Error("failed to marshal task streak", zap.Error(err))
+		return err
+	}
+	leaderboard, err := marshalLeaderboard(task)
+	if err != nil {
+		r.log.Error("failed to marshal task leaderboard", zap.Error(err))
+		return err
+	}
+
+	var (
+		id        string
+		createdAt time.Time
+	)
+	if err := r.db.QueryRow(
+		ctx,
+		query,
+		task.Title(),
+		nullableString(task.Description()),
+		task.Type(),
+		task.Target(),
+		nullableJSON(task.Reward()),
+		tiers,
+		task.RepeatLimit(),
+		rule,
+		task.Aggregation().Mode,
+		nullableString(task.Aggregation().Attribute),
+		int64(task.Aggregation().Window/time.Second),
+		steps,
+		task.SeasonXP(),
+		streak,
+		task.Scope(),
+		leaderboard,
+		task.IsActive(),
+		task.ResetPeriod(),
+		nullableTime(task.StartsAt()),
+		nullableTime(task.EndsAt()),
+	).Scan(&id, &createdAt); err != nil {
+		r.log.Error("failed to create task", zap.Error(err))
+		return err
+	}
+	task.SetID(id)
+	task.SetCreatedAt(createdAt)
+	return nil
+}
+
+func (r *TaskRepository) Update(ctx context.Context, task *entities.Task) error {
+	query := `UPDATE tasks
+		SET title = $2, description = $3, type = $4, target = $5, reward = $6, tiers = $7, repeat_limit = $8,
+			rule = $9, aggregation_mode = $10, aggregation_attribute = $11,
+			aggregation_window_seconds = $12, aggregation_steps = $13, season_xp = $14, streak = $15,
+			scope = $16, leaderboard = $17, is_active = $18, reset_period = $19, starts_at = $20, ends_at = $21
+		WHERE id = $1
+		RETURNING created_at`
+
+	tiers, err := marshalTiers(task)
+	if err != nil {
+		r.log.Error("failed to marshal task tiers", zap.Error(err))
+		return err
+	}
+	rule, err := marshalRule(task)
+	if err != nil {
+		r.log.Error("failed to marshal task rule", zap.Error(err))
+		return err
+	}
+	steps, err := marshalSteps(task)
+	if err != nil {
+		r.log.Error("failed to marshal task aggregation steps", zap.Error(err))
+		return err
+	}
+	streak, err := marshalStreak(task)
+	if err != nil {
+		r.log.Error("failed to marshal task streak", zap.Error(err))
+		return err
+	}
+	leaderboard, err := marshalLeaderboard(task)
+	if err != nil {
+		r.log.Error("failed to marshal task leaderboard", zap.Error(err))
+		return err
+	}
+
+	var createdAt time.Time
+	if err := r.db.QueryRow(
+		ctx,
+		query,
+		task.ID(),
+		task.Title(),
+		nullableString(task.Description()),
+		task.Type(),
+		task.Target(),
+		nullableJSON(task.Reward()),
+		tiers,
+		task.RepeatLimit(),
+		rule,
+		task.Aggregation().Mode,
+		nullableString(task.Aggregation().Attribute),
+		int64(task.Aggregation().Window/time.Second),
+		steps,
+		task.SeasonXP(),
+		streak,
+		task.Scope(),
+		leaderboard,
+		task.IsActive(),
+		task.ResetPeriod(),
+		nullableTime(task.StartsAt()),
+		nullableTime(task.EndsAt()),
+	).Scan(&createdAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return exceptions.ErrTaskNotFound
+		}
+		r.log.Error("failed to update task", zap.Error(err))
+		return err
+	}
+	task.SetCreatedAt(createdAt)
+	return nil
+}
+
+func (r *TaskRepository) Deactivate(ctx context.Context, id string) error {
+	query := `UPDATE tasks SET is_active = false WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("failed to deactivate task", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return exceptions.ErrTaskNotFound
+	}
+	return nil
+}
+
+func (r *TaskRepository) SetPrerequisites(ctx context.Context, taskID string, prerequisiteIDs []string) error {
+	deleteQuery := `DELETE FROM task_prerequisites WHERE task_id = $1`
+	if _, err := r.db.Exec(ctx, deleteQuery, taskID); err != nil {
+		r.log.Error("failed to clear task prerequisites", zap.Error(err))
+		return err
+	}
+	if len(prerequisiteIDs) == 0 {
+		return nil
+	}
+
+	insertQuery := `INSERT INTO task_prerequisites (task_id, prerequisite_id)
+		SELECT $1, unnest($2::uuid[])`
+	if _, err := r.db.Exec(ctx, insertQuery, taskID, prerequisiteIDs); err != nil {
+		r.log.Error("failed to set task prerequisites", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *TaskRepository) SetChildren(ctx context.Context, questID string, childIDs []string) error {
+	deleteQuery := `DELETE FROM task_quest_children WHERE quest_id = $1`
+	if _, err := r.db.Exec(ctx, deleteQuery, questID); err != nil {
+		r.log.Error("failed to clear quest children", zap.Error(err))
+		return err
+	}
+	if len(childIDs) == 0 {
+		return nil
+	}
+
+	insertQuery := `INSERT INTO task_quest_children (quest_id, child_id)
+		SELECT $1, unnest($2::uuid[])`
+	if _, err := r.db.Exec(ctx, insertQuery, questID, childIDs); err != nil {
+		r.log.Error("failed to set quest children", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *TaskRepository) list(ctx context.Context, query string, args ...any) ([]*entities.Task, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("failed to list tasks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]*entities.Task, 0)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			r.log.Error("failed to scan task row", zap.Error(err))
+			return nil, err
+		}
+		r.checkRewards(task)
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error("failed to iterate task rows", zap.Error(err))
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// checkRewards flags tasks whose stored rewards predate the reward schema. They
+// still load, with only the raw reward JSON available, but cannot be saved
+// until their rewards are fixed.
+func (r *TaskRepository) checkRewards(task *entities.Task) {
+	if !task.RewardsMatchSchema() {
+		r.log.Warn("task reward does not match the reward schema", zap.String("task_id", task.ID()))
+	}
+}
+
+func scanTask(row rowScanner) (*entities.Task, error) {
 	var (
 		taskID      string
 		title       string
@@ -42,79 +327,169 @@ func (r *TaskRepository) GetByID(ctx context.Context, id string) (*entities.Task
 		taskType    entities.TaskType
 		target      int
 		reward      []byte
+		tiersJSON   []byte
+		repeatLimit int
+		ruleJSON    []byte
+		aggMode     entities.AggregationMode
+		aggAttr     sql.NullString
+		aggWindow   int64
+		stepsJSON   []byte
+		seasonXP    int
+		streakJSON  []byte
+		scope       entities.TaskScope
+		boardJSON   []byte
 		isActive    bool
+		resetPeriod entities.ResetPeriod
+		startsAt    sql.NullTime
+		endsAt      sql.NullTime
 		createdAt   time.Time
+		prereqs     []string
+		children    []string
+		questIDs    []string
+		streakIDs   []string
 	)
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	if err := row.Scan(
 		&taskID,
 		&title,
 		&description,
 		&taskType,
 		&target,
 		&reward,
+		&tiersJSON,
+		&repeatLimit,
+		&ruleJSON,
+		&aggMode,
+		&aggAttr,
+		&aggWindow,
+		&stepsJSON,
+		&seasonXP,
+		&streakJSON,
+		&scope,
+		&boardJSON,
 		&isActive,
+		&resetPeriod,
+		&startsAt,
+		&endsAt,
 		&createdAt,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, exceptions.ErrTaskNotFound
-		}
+		&prereqs,
+		&children,
+		&questIDs,
+		&streakIDs,
+	); err != nil {
 		return nil, err
 	}
 	desc := ""
 	if description.Valid {
 		desc = description.String
 	}
-	return entities.NewTask(taskID, title, desc, taskType, target, reward, isActive, createdAt), nil
+	task := entities.NewTask(taskID, title, desc, taskType, target, reward, isActive, createdAt)
+	if len(tiersJSON) > 0 {
+		var tiers []entities.TaskTier
+		if err := json.Unmarshal(tiersJSON, &tiers); err != nil {
+			return nil, err
+		}
+		task.SetTiers(tiers)
+	}
+	if len(ruleJSON) > 0 {
+		var rule entities.TaskRule
+		if err := json.Unmarshal(ruleJSON, &rule); err != nil {
+			return nil, err
+		}
+		task.SetRule(&rule)
+	}
+	aggregation := entities.Aggregation{
+		Mode:      aggMode,
+		Attribute: aggAttr.String,
+		Window:    time.Duration(aggWindow) * time.Second,
+	}
+	if len(stepsJSON) > 0 {
+		if err := json.Unmarshal(stepsJSON, &aggregation.Steps); err != nil {
+			return nil, err
+		}
+	}
+	task.SetAggregation(aggregation)
+	task.SetSeasonXP(seasonXP)
+	if len(streakJSON) > 0 {
+		var streak entities.StreakConfig
+		if err := json.Unmarshal(streakJSON, &streak); err != nil {
+			return nil, err
+		}
+		task.SetStreak(&streak)
+	}
+	task.SetScope(scope)
+	if len(boardJSON) > 0 {
+		var leaderboard entities.LeaderboardConfig
+		if err := json.Unmarshal(boardJSON, &leaderboard); err != nil {
+			return nil, err
+		}
+		task.SetLeaderboard(&leaderboard)
+	}
+	task.SetRepeatLimit(repeatLimit)
+	task.SetResetPeriod(resetPeriod)
+	task.SetSchedule(startsAt.Time, endsAt.Time)
+	task.SetPrerequisites(prereqs)
+	task.SetChildren(children)
+	task.SetQuestIDs(questIDs)
+	task.SetStreakIDs(streakIDs)
+	return task, nil
 }
 
-func (r *TaskRepository) ListActive(ctx context.Context) ([]*entities.Task, error) {
-	query := `SELECT id, title, description, type, target, reward, is_active, created_at
-	FROM tasks WHERE is_active = true`
+func marshalTiers(task *entities.Task) (any, error) {
+	if !task.HasTiers() {
+		return nil, nil
+	}
+	return json.Marshal(task.Tiers())
+}
 
-	rows, err := r.db.Query(ctx, query)
-	if err != nil {
-		r.log.Error("failed to list active tasks", zap.Error(err))
-		return nil, err
+func marshalRule(task *entities.Task) (any, error) {
+	rule := task.Rule()
+	if rule == nil {
+		return nil, nil
 	}
-	defer rows.Close()
+	return json.Marshal(rule)
+}
 
-	tasks := make([]*entities.Task, 0)
-	for rows.Next() {
-		var (
-			taskID      string
-			title       string
-			description sql.NullString
-			taskType    entities.TaskType
-			target      int
-			reward      []byte
-			isActive    bool
-			createdAt   time.Time
-		)
-		if err := rows.Scan(
-			&taskID,
-			&title,
-			&description,
-			&taskType,
-			&target,
-			&reward,
-			&isActive,
-			&createdAt,
-		); err != nil {
-			r.log.Error("failed to scan task row", zap.Error(err))
-			return nil, err
-		}
-		desc := ""
-		if description.Valid {
-			desc = description.String
-		}
-		tasks = append(tasks, entities.NewTask(taskID, title, desc, taskType, target, reward, isActive, createdAt))
+func marshalSteps(task *entities.Task) (any, error) {
+	steps := task.Aggregation().Steps
+	if len(steps) == 0 {
+		return nil, nil
 	}
+	return json.Marshal(steps)
+}
 
-	if err := rows.Err(); err != nil {
-		r.log.Error("failed to iterate task rows", zap.Error(err))
-		return nil, err
+func marshalStreak(task *entities.Task) (any, error) {
+	streak := task.Streak()
+	if streak == nil {
+		return nil, nil
 	}
+	return json.Marshal(streak)
+}
 
-	return tasks, nil
+func marshalLeaderboard(task *entities.Task) (any, error) {
+	leaderboard := task.Leaderboard()
+	if leaderboard == nil {
+		return nil, nil
+	}
+	return json.Marshal(leaderboard)
+}
+
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func nullableJSON(value []byte) any {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
+
+func nullableTime(value time.Time) any {
+	if value.IsZero() {
+		return nil
+	}
+	return value
 }
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/infrastructure/db"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type UserRepository struct {
+	db  db.Querier
+	log *zap.Logger
+}
+
+func NewUserRepository(db db.Querier, log *zap.Logger) *UserRepository {
+	if db == nil {
+		log.Fatal("database querier is nil")
+	}
+	if log == nil {
+		log.Fatal("logger is nil")
+	}
+	return &UserRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *UserRepository) GetSettings(ctx context.Context, userID string) (*entities.UserSettings, error) {
+	query := `SELECT timezone, COALESCE(pending_timezone, ''), pending_from, COALESCE(updated_at, NOW())
+		FROM user_settings
+		WHERE user_id = $1`
+
+	settings := &entities.UserSettings{UserID: userID}
+	var pendingFrom *time.Time
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&settings.Timezone, &settings.PendingTimezone, &pendingFrom, &settings.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, exceptions.ErrUserSettingsNotFound
+		}
+		r.log.Error("failed to get user settings", zap.Error(err))
+		return nil, err
+	}
+	if pendingFrom != nil {
+		settings.PendingFrom = *pendingFrom
+	}
+	return settings, nil
+}
+
+func (r *UserRepository) CreateSettings(ctx context.Context, settings *entities.UserSettings) error {
+	query := `INSERT INTO user_settings (user_id, timezone, updated_at)
+		VALUES ($1, $2, COALESCE($3, NOW()))
+		ON CONFLICT (user_id) DO NOTHING`
+
+	if _, err := r.db.Exec(ctx, query, settings.UserID, settings.Timezone, nullableTime(settings.UpdatedAt)); err != nil {
+		r.log.Error("failed to create user settings", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *UserRepository) SaveSettings(ctx context.Context, settings *entities.UserSettings) error {
+	query := `INSERT INTO user_settings (user_id, timezone, pending_timezone, pending_from, updated_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, NOW()))
+		ON CONFLICT (user_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone,
+			pending_timezone = EXCLUDED.pending_timezone,
+			pending_from = EXCLUDED.pending_from,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.Exec(
+		ctx,
+		query,
+		settings.UserID,
+		settings.Timezone,
+		nullableString(settings.PendingTimezone),
+		nullableTime(settings.PendingFrom),
+		nullableTime(settings.UpdatedAt),
+	); err != nil {
+		r.log.Error("failed to save user settings", zap.Error(err))
+		return err
+	}
+	return nil
+}
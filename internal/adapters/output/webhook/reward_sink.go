@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+
+	"go.uber.org/zap"
+)
+
+// maxErrorBody caps how much of a failed response is kept as the delivery error.
+const maxErrorBody = 512
+
+// RewardSink posts each grant as JSON to a webhook. The grant ID is sent as the
+// Idempotency-Key header so the receiver can drop redeliveries.
+type RewardSink struct {
+	url    string
+	client *http.Client
+	log    *zap.Logger
+}
+
+type rewardPayload struct {
+	GrantID   string          `json:"grant_id"`
+	UserID    string          `json:"user_id"`
+	Source    string          `json:"source"`
+	SourceID  string          `json:"source_id"`
+	TaskID    string          `json:"task_id,omitempty"`
+	PeriodKey string          `json:"period_key"`
+	Tier      int             `json:"tier"`
+	Reward    json.RawMessage `json:"reward"`
+	ClaimedAt time.Time       `json:"claimed_at"`
+}
+
+func NewRewardSink(url string, timeout time.Duration, log *zap.Logger) *RewardSink {
+	if log == nil {
+		panic("logger is nil")
+	}
+	if url == "" {
+		log.Fatal("reward webhook url is empty")
+	}
+	return &RewardSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		log:    log,
+	}
+}
+
+func (s *RewardSink) Deliver(ctx context.Context, grant *entities.RewardGrant) error {
+	body, err := json.Marshal(rewardPayload{
+		GrantID:   grant.ID,
+		UserID:    grant.UserID,
+		Source:    string(grant.Source),
+		SourceID:  grant.SourceID,
+		TaskID:    grant.TaskID,
+		PeriodKey: grant.PeriodKey,
+		Tier:      grant.Tier,
+		Reward:    grant.Reward,
+		ClaimedAt: grant.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", grant.ID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("reward webhook returned %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	s.log.Debug("reward delivered to webhook", zap.String("grant_id", grant.ID), zap.String("user_id", grant.UserID))
+	return nil
+}
@@ -15,6 +15,8 @@ type Config struct {
 	Logger   LoggerConfig
 	Database DatabaseConfig
 	GRPC     GRPCConfig
+	Tasks    TasksConfig
+	Rewards  RewardsConfig
 }
 
 type LoggerConfig struct {
@@ -36,14 +38,51 @@ type GRPCConfig struct {
 	SubscribeProgressMaxPeriod time.Duration
 }
 
+type TasksConfig struct {
+	PeriodTimezone   string
+	MaxSessionLength time.Duration
+	// SessionExpiryInterval is how often sessions open for longer than
+	// MaxSessionLength are closed and credited.
+	SessionExpiryInterval time.Duration
+	// MaxEventLateness is how old an event may be and still count towards
+	// window and sequence tasks.
+	MaxEventLateness time.Duration
+}
+
+// RewardsConfig controls delivery of claimed rewards. Sink is "ledger" to
+// credit currencies and items to the built-in ledger, which rejects rewards
+// holding XP or bundles, or "memory" or "webhook" to deliver whole rewards
+// elsewhere; the webhook sink needs WebhookURL. Sink must be set explicitly
+// outside the development environment, where it defaults to "memory".
+type RewardsConfig struct {
+	Sink              string
+	WebhookURL        string
+	WebhookTimeout    time.Duration
+	DispatchInterval  time.Duration
+	DispatchBatchSize int
+	DispatchLease     time.Duration
+	MaxAttempts       int
+	RetryDelay        time.Duration
+	RetryMaxDelay     time.Duration
+}
+
 func Load() (*Config, error) {
 	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return nil, fmt.Errorf("error loading .env file: %w", err)
 	}
 
+	env := getEnv("LOGGER_ENV", "development")
+	rewardSink := getEnv("REWARDS_SINK", "")
+	if rewardSink == "" {
+		if env != "development" {
+			return nil, errors.New("REWARDS_SINK must be set outside the development environment")
+		}
+		rewardSink = "memory"
+	}
+
 	return &Config{
 		Logger: LoggerConfig{
-			Env: getEnv("LOGGER_ENV", "development"),
+			Env: env,
 		},
 		Database: DatabaseConfig{
 			Name:     getEnv("POSTGRES_DB", "task_manager"),
@@ -58,6 +97,22 @@ func Load() (*Config, error) {
 			SubscribeProgressInterval:  getEnvDuration("GRPC_SUBSCRIBE_PROGRESS_INTERVAL", 2*time.Second),
 			SubscribeProgressMaxPeriod: getEnvDuration("GRPC_SUBSCRIBE_PROGRESS_MAX_PERIOD", 5*time.Minute),
 		},
+		Tasks: TasksConfig{
+			PeriodTimezone:   getEnv("TASKS_PERIOD_TIMEZONE", "UTC"),
+			MaxSessionLength: getEnvDuration("TASKS_MAX_SESSION_LENGTH", 4*time.Hour),
+			MaxEventLateness: getEnvDuration("TASKS_MAX_EVENT_LATENESS", 24*time.Hour),
+		},
+		Rewards: RewardsConfig{
+			Sink:              rewardSink,
+			WebhookURL:        getEnv("REWARDS_WEBHOOK_URL", ""),
+			WebhookTimeout:    getEnvDuration("REWARDS_WEBHOOK_TIMEOUT", 10*time.Second),
+			DispatchInterval:  getEnvDuration("REWARDS_DISPATCH_INTERVAL", time.Second),
+			DispatchBatchSize: getEnvInt("REWARDS_DISPATCH_BATCH_SIZE", 100),
+			DispatchLease:     getEnvDuration("REWARDS_DISPATCH_LEASE", time.Minute),
+			MaxAttempts:       getEnvInt("REWARDS_MAX_ATTEMPTS", 10),
+			RetryDelay:        getEnvDuration("REWARDS_RETRY_DELAY", 5*time.Second),
+			RetryMaxDelay:     getEnvDuration("REWARDS_RETRY_MAX_DELAY", time.Hour),
+		},
 	}, nil
 }
 
@@ -0,0 +1,188 @@
+package entities
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+// Lifetime counters maintained per user. Counters qualified with a task type
+// or event type are named "<counter>:<qualifier>", e.g. "tasks_claimed:game".
+const (
+	CounterEventsProcessed = "events_processed"
+	CounterTasksCompleted  = "tasks_completed"
+	CounterTasksClaimed    = "tasks_claimed"
+)
+
+// QualifiedCounter returns the counter narrowed to a task or event type.
+func QualifiedCounter(counter, qualifier string) string {
+	return counter + ":" + qualifier
+}
+
+type AchievementCriterionKind string
+
+const (
+	// CriterionCounter holds once the counter reaches Threshold.
+	CriterionCounter AchievementCriterionKind = "counter"
+	// CriterionAllTasks holds once the user has completed every active task
+	// of TaskType, or every active task when TaskType is empty.
+	CriterionAllTasks AchievementCriterionKind = "all_tasks"
+)
+
+type AchievementCriterion struct {
+	Kind      AchievementCriterionKind `json:"kind"`
+	Counter   string                   `json:"counter,omitempty"`
+	Threshold int64                    `json:"threshold,omitempty"`
+	TaskType  TaskType                 `json:"task_type,omitempty"`
+}
+
+// CounterKey is the lifetime counter the criterion is evaluated against.
+func (c AchievementCriterion) CounterKey() string {
+	if c.Kind == CriterionAllTasks {
+		if c.TaskType == "" {
+			return CounterTasksCompleted
+		}
+		return QualifiedCounter(CounterTasksCompleted, string(c.TaskType))
+	}
+	return c.Counter
+}
+
+func (c AchievementCriterion) Validate() error {
+	switch c.Kind {
+	case CriterionCounter:
+		if strings.TrimSpace(c.Counter) == "" || c.Threshold <= 0 || c.TaskType != "" {
+			return exceptions.ErrAchievementInvalid
+		}
+	case CriterionAllTasks:
+		if c.Counter != "" || c.Threshold != 0 || (c.TaskType != "" && !c.TaskType.IsValid()) {
+			return exceptions.ErrAchievementInvalid
+		}
+	default:
+		return exceptions.ErrAchievementInvalid
+	}
+	return nil
+}
+
+// Achievement is a permanent badge unlocked once all of its criteria hold.
+type Achievement struct {
+	id          string
+	title       string
+	description string
+	badge       string
+	criteria    []AchievementCriterion
+	reward      json.RawMessage
+	createdAt   time.Time
+}
+
+func NewAchievement(id, title, description, badge string, criteria []AchievementCriterion, reward json.RawMessage, createdAt time.Time) *Achievement {
+	achievement := &Achievement{
+		id:          id,
+		title:       title,
+		description: description,
+		badge:       badge,
+		criteria:    append([]AchievementCriterion(nil), criteria...),
+		createdAt:   createdAt,
+	}
+	if len(reward) > 0 {
+		achievement.reward = append(json.RawMessage(nil), reward...)
+	}
+	return achievement
+}
+
+func (a *Achievement) ID() string {
+	return a.id
+}
+
+func (a *Achievement) Title() string {
+	return a.title
+}
+
+func (a *Achievement) Description() string {
+	return a.description
+}
+
+// Badge identifies the badge artwork shown by clients.
+func (a *Achievement) Badge() string {
+	return a.badge
+}
+
+func (a *Achievement) Criteria() []AchievementCriterion {
+	return append([]AchievementCriterion(nil), a.criteria...)
+}
+
+func (a *Achievement) Reward() json.RawMessage {
+	if len(a.reward) == 0 {
+		return nil
+	}
+	return append(json.RawMessage(nil), a.reward...)
+}
+
+func (a *Achievement) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Achievement) SetID(id string) {
+	a.id = id
+}
+
+func (a *Achievement) SetCreatedAt(createdAt time.Time) {
+	a.createdAt = createdAt
+}
+
+// DependsOn reports whether any criterion reads one of the counters.
+func (a *Achievement) DependsOn(counters []string) bool {
+	for _, criterion := range a.criteria {
+		for _, counter := range counters {
+			if criterion.CounterKey() == counter {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsMet evaluates the criteria against the user's counters. tasksLeft maps
+// the counter of each all_tasks criterion to the number of active tasks the
+// user has not completed yet; a criterion without active tasks is never met.
+func (a *Achievement) IsMet(counters map[string]int64, tasksLeft map[string]int64) bool {
+	for _, criterion := range a.criteria {
+		if criterion.Kind == CriterionAllTasks {
+			left, ok := tasksLeft[criterion.CounterKey()]
+			if !ok || left > 0 {
+				return false
+			}
+			continue
+		}
+		if counters[criterion.CounterKey()] < criterion.Threshold {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Achievement) Validate() error {
+	if strings.TrimSpace(a.title) == "" || len(a.criteria) == 0 {
+		return exceptions.ErrAchievementInvalid
+	}
+	for _, criterion := range a.criteria {
+		if err := criterion.Validate(); err != nil {
+			return err
+		}
+	}
+	if !isValidReward(a.reward) {
+		return exceptions.ErrTaskRewardInvalid
+	}
+	return nil
+}
+
+// UserAchievement is an achievement as seen by one user.
+type UserAchievement struct {
+	Achievement *Achievement
+	UnlockedAt  time.Time
+}
+
+func (u UserAchievement) Unlocked() bool {
+	return !u.UnlockedAt.IsZero()
+}
@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+type AggregationMode string
+
+const (
+	// AggregationSum adds every event value to the progress.
+	AggregationSum AggregationMode = "sum"
+	// AggregationMax keeps the best value seen so far.
+	AggregationMax AggregationMode = "max"
+	// AggregationSet replaces the progress with the latest value.
+	AggregationSet AggregationMode = "set"
+	// AggregationDistinct counts distinct values of an attribute.
+	AggregationDistinct AggregationMode = "distinct"
+	// AggregationWindow counts matching events that fall within Window of each other.
+	AggregationWindow AggregationMode = "window"
+	// AggregationSequence tracks Steps matched in order, optionally within Window.
+	AggregationSequence AggregationMode = "sequence"
+)
+
+// Aggregation defines how event values turn into progress. Attribute names the
+// event attribute holding the value; without it the event amount is used.
+// Window and Steps configure the window and sequence modes.
+type Aggregation struct {
+	Mode      AggregationMode
+	Attribute string
+	Window    time.Duration
+	Steps     []TaskRule
+}
+
+func (a Aggregation) Validate() error {
+	if a.Window < 0 {
+		return exceptions.ErrTaskAggregationInvalid
+	}
+	switch a.Mode {
+	case AggregationSum, AggregationMax, AggregationSet:
+	case AggregationDistinct:
+		if strings.TrimSpace(a.Attribute) == "" {
+			return exceptions.ErrTaskAggregationInvalid
+		}
+	case AggregationWindow:
+		if a.Window == 0 || len(a.Steps) > 0 {
+			return exceptions.ErrTaskAggregationInvalid
+		}
+		return nil
+	case AggregationSequence:
+		if len(a.Steps) < 2 {
+			return exceptions.ErrTaskAggregationInvalid
+		}
+		for i := range a.Steps {
+			if err := a.Steps[i].Validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return exceptions.ErrTaskAggregationInvalid
+	}
+	if a.Window != 0 || len(a.Steps) > 0 {
+		return exceptions.ErrTaskAggregationInvalid
+	}
+	return nil
+}
+
+// IsTimed reports whether the mode evaluates events by their own timestamps
+// and therefore keeps per-event state.
+func (a Aggregation) IsTimed() bool {
+	return a.Mode == AggregationWindow || a.Mode == AggregationSequence
+}
+
+// MatchedSteps returns the 0-based sequence steps the event satisfies. Window
+// mode has a single implicit step that every event satisfies.
+func (a Aggregation) MatchedSteps(event *TaskEvent) []int {
+	if a.Mode == AggregationWindow {
+		return []int{0}
+	}
+	steps := make([]int, 0, 1)
+	for i := range a.Steps {
+		if a.Steps[i].Matches(event) {
+			steps = append(steps, i)
+		}
+	}
+	return steps
+}
+
+func (a Aggregation) clone() Aggregation {
+	clone := a
+	if len(a.Steps) > 0 {
+		clone.Steps = make([]TaskRule, 0, len(a.Steps))
+		for i := range a.Steps {
+			clone.Steps = append(clone.Steps, *a.Steps[i].clone())
+		}
+	}
+	return clone
+}
+
+// NumericValue returns the value an event contributes in sum, max and set modes.
+// Values that do not fit a progress counter fail with ErrEventValueOutOfRange.
+func (a Aggregation) NumericValue(event *TaskEvent) (int, error) {
+	if a.Attribute == "" {
+		return event.Amount(), nil
+	}
+	value, ok := event.Attributes()[a.Attribute]
+	if !ok {
+		return 0, exceptions.ErrEventAttributeMissing
+	}
+	number, ok := value.(float64)
+	if !ok || math.IsNaN(number) || math.IsInf(number, 0) || number < 0 {
+		return 0, exceptions.ErrEventAttributeMissing
+	}
+	// Progress is stored in INTEGER columns.
+	if number > math.MaxInt32 {
+		return 0, exceptions.ErrEventValueOutOfRange
+	}
+	return int(number), nil
+}
+
+// DistinctValue returns the attribute value counted in distinct mode.
+func (a Aggregation) DistinctValue(event *TaskEvent) (string, error) {
+	value, ok := event.Attributes().Text(a.Attribute)
+	if !ok || value == "" {
+		return "", exceptions.ErrEventAttributeMissing
+	}
+	return value, nil
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+// EventAttributes carries game-specific event data such as score, map or mode.
+// Values are limited to strings, float64 numbers and booleans.
+type EventAttributes map[string]any
+
+func NewEventAttributes(values map[string]any) (EventAttributes, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	attributes := make(EventAttributes, len(values))
+	for key, value := range values {
+		if strings.TrimSpace(key) == "" {
+			return nil, exceptions.ErrEventAttributesInvalid
+		}
+		switch typed := value.(type) {
+		case string, bool, float64:
+			attributes[key] = typed
+		case int:
+			attributes[key] = float64(typed)
+		case int32:
+			attributes[key] = float64(typed)
+		case int64:
+			attributes[key] = float64(typed)
+		default:
+			return nil, exceptions.ErrEventAttributesInvalid
+		}
+	}
+	return attributes, nil
+}
+
+// Text returns the attribute formatted as a string, the form used by rule filters.
+func (a EventAttributes) Text(key string) (string, bool) {
+	value, ok := a[key]
+	if !ok {
+		return "", false
+	}
+	switch typed := value.(type) {
+	case string:
+		return typed, true
+	case bool:
+		return strconv.FormatBool(typed), true
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+func (a EventAttributes) clone() EventAttributes {
+	if len(a) == 0 {
+		return nil
+	}
+	clone := make(EventAttributes, len(a))
+	for key, value := range a {
+		clone[key] = value
+	}
+	return clone
+}
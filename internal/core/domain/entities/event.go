@@ -12,6 +12,10 @@ const (
 	EventTypeProgressUpdate  TaskEventType = "progress_update"
 	EventTypeTaskSubscribed  TaskEventType = "task_subscribed"
 	EventTypeTaskStepCounted TaskEventType = "task_step_counted"
+	// EventTypeSessionStarted and EventTypeSessionStopped bracket a play session.
+	// The stop event advances matching tasks by the elapsed minutes.
+	EventTypeSessionStarted TaskEventType = "session_started"
+	EventTypeSessionStopped TaskEventType = "session_stopped"
 )
 
 type TaskEvent struct {
@@ -20,6 +24,8 @@ type TaskEvent struct {
 	roomID      string
 	eventType   TaskEventType
 	payload     *ProgressPayload
+	attributes  EventAttributes
+	timezone    string
 	createdAt   time.Time
 	processedAt time.Time
 }
@@ -55,6 +61,47 @@ func (e *TaskEvent) Type() TaskEventType {
 	return e.eventType
 }
 
+// TaskID is the task named by the payload; empty when the event is matched by task rules.
+func (e *TaskEvent) TaskID() string {
+	if e.payload == nil {
+		return ""
+	}
+	return e.payload.TaskID
+}
+
+// Amount is the progress increment carried by the event, 1 when no payload is sent.
+func (e *TaskEvent) Amount() int {
+	if e.payload == nil {
+		return 1
+	}
+	return e.payload.Amount
+}
+
+// Attributes returns the event attributes available to task matching. The room
+// ID is exposed as "room_id" unless the sender provided that key itself.
+func (e *TaskEvent) Attributes() EventAttributes {
+	attributes := e.attributes.clone()
+	if e.roomID == "" {
+		return attributes
+	}
+	if attributes == nil {
+		attributes = make(EventAttributes, 1)
+	}
+	if _, ok := attributes["room_id"]; !ok {
+		attributes["room_id"] = e.roomID
+	}
+	return attributes
+}
+
+// RawAttributes returns only the attributes sent with the event.
+func (e *TaskEvent) RawAttributes() EventAttributes {
+	return e.attributes.clone()
+}
+
+func (e *TaskEvent) SetAttributes(attributes EventAttributes) {
+	e.attributes = attributes.clone()
+}
+
 func (e *TaskEvent) Payload() *ProgressPayload {
 	if e.payload == nil {
 		return nil
@@ -65,6 +112,21 @@ func (e *TaskEvent) Payload() *ProgressPayload {
 	}
 }
 
+// Timezone is the user's IANA timezone reported by the client, if any.
+func (e *TaskEvent) Timezone() string {
+	return e.timezone
+}
+
+func (e *TaskEvent) SetTimezone(timezone string) error {
+	if timezone != "" {
+		if _, err := LoadTimezone(timezone); err != nil {
+			return err
+		}
+	}
+	e.timezone = timezone
+	return nil
+}
+
 func (e *TaskEvent) CreatedAt() time.Time {
 	return e.createdAt
 }
@@ -90,13 +152,10 @@ func (e *TaskEvent) Validate() error {
 	if e.eventType == "" {
 		return exceptions.ErrEventTypeRequired
 	}
-	if !e.isSupportedEventType() {
-		return exceptions.ErrUnsupportedEventType
+	if e.requiresProgressPayload() && e.payload == nil {
+		return exceptions.ErrEventPayloadInvalid
 	}
-	if e.requiresProgressPayload() {
-		if e.payload == nil {
-			return exceptions.ErrEventPayloadInvalid
-		}
+	if e.payload != nil {
 		if err := e.payload.Validate(); err != nil {
 			return err
 		}
@@ -112,12 +171,3 @@ func (e *TaskEvent) requiresProgressPayload() bool {
 		return false
 	}
 }
-
-func (e *TaskEvent) isSupportedEventType() bool {
-	switch e.eventType {
-	case EventTypeProgressUpdate, EventTypeTaskSubscribed, EventTypeTaskStepCounted:
-		return true
-	default:
-		return false
-	}
-}
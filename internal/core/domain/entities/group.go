@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+// Group is a persistent clan. A user belongs to at most one group at a time.
+type Group struct {
+	id        string
+	name      string
+	createdAt time.Time
+}
+
+func NewGroup(id, name string, createdAt time.Time) *Group {
+	return &Group{
+		id:        id,
+		name:      name,
+		createdAt: createdAt,
+	}
+}
+
+func (g *Group) ID() string {
+	return g.id
+}
+
+func (g *Group) Name() string {
+	return g.name
+}
+
+func (g *Group) CreatedAt() time.Time {
+	return g.createdAt
+}
+
+func (g *Group) SetID(id string) {
+	g.id = id
+}
+
+func (g *Group) SetCreatedAt(createdAt time.Time) {
+	g.createdAt = createdAt
+}
+
+func (g *Group) Validate() error {
+	if strings.TrimSpace(g.name) == "" {
+		return exceptions.ErrGroupInvalid
+	}
+	return nil
+}
+
+type GroupMember struct {
+	GroupID  string
+	UserID   string
+	JoinedAt time.Time
+}
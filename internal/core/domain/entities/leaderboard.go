@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+// LeaderboardConfig ranks users by their progress on a task. Period sets the
+// leaderboard buckets independently of the task reset period; without one the
+// leaderboard is all-time. Buckets follow the service period timezone so every
+// user lands in the same bucket.
+type LeaderboardConfig struct {
+	Period ResetPeriod `json:"period,omitempty"`
+}
+
+func (c *LeaderboardConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if !c.Period.IsValid() {
+		return exceptions.ErrTaskLeaderboardInvalid
+	}
+	return nil
+}
+
+func (c *LeaderboardConfig) clone() *LeaderboardConfig {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	return &clone
+}
+
+// Leaderboard is a ranked slice of one leaderboard bucket. Total counts every
+// user in the bucket.
+type Leaderboard struct {
+	TaskID    string
+	PeriodKey string
+	Total     int
+	Entries   []LeaderboardEntry
+}
+
+// LeaderboardEntry is a user's score in one leaderboard bucket. Unlike task
+// progress the score is not capped at the target. Users with equal scores are
+// ordered by TieAt: the time they completed the task, or before completing it,
+// the time they reached their score.
+type LeaderboardEntry struct {
+	TaskID      string
+	PeriodKey   string
+	UserID      string
+	Score       int64
+	CompletedAt time.Time
+	TieAt       time.Time
+	Rank        int
+}
+
+func NewLeaderboardEntry(taskID, periodKey, userID string) *LeaderboardEntry {
+	return &LeaderboardEntry{
+		TaskID:    taskID,
+		PeriodKey: periodKey,
+		UserID:    userID,
+	}
+}
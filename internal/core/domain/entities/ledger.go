@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+type AssetKind string
+
+const (
+	AssetCurrency AssetKind = "currency"
+	AssetItem     AssetKind = "item"
+)
+
+type LedgerKind string
+
+const (
+	LedgerKindReward LedgerKind = "reward"
+	LedgerKindSpend  LedgerKind = "spend"
+)
+
+// System accounts are the other side of every user posting: rewards are
+// issued from LedgerAccountRewards and spending goes to LedgerAccountSpent.
+// Only user accounts have balances, which cannot go negative.
+const (
+	LedgerAccountRewards = "system:rewards"
+	LedgerAccountSpent   = "system:spent"
+)
+
+// LedgerEntry moves Amount of an asset into Account; debits are negative.
+// System is set on system account entries. It is never derived from the
+// account name, since user IDs come from clients.
+type LedgerEntry struct {
+	Account   string
+	System    bool
+	AssetKind AssetKind
+	Asset     string
+	Amount    int64
+}
+
+// LedgerTransaction is a balanced set of entries for one user. Reference is
+// unique per user and kind: the reward grant ID for rewards and the caller's
+// key for spending, so retries are not posted twice.
+type LedgerTransaction struct {
+	ID        string
+	UserID    string
+	Kind      LedgerKind
+	Reference string
+	Entries   []LedgerEntry
+	CreatedAt time.Time
+}
+
+// NewRewardCredit credits the reward's currencies and items to the user. It
+// returns nil when the reward holds neither. XP and bundles have no ledger
+// asset; callers only credit rewards that are LedgerOnly.
+func NewRewardCredit(userID, reference string, reward *Reward) *LedgerTransaction {
+	if reward == nil || (len(reward.Currencies) == 0 && len(reward.Items) == 0) {
+		return nil
+	}
+	tx := &LedgerTransaction{
+		UserID:    userID,
+		Kind:      LedgerKindReward,
+		Reference: reference,
+		Entries:   make([]LedgerEntry, 0, 2*(len(reward.Currencies)+len(reward.Items))),
+	}
+	for _, currency := range reward.Currencies {
+		tx.issue(AssetCurrency, currency.Currency, currency.Amount)
+	}
+	for _, item := range reward.Items {
+		tx.issue(AssetItem, item.ItemID, int64(item.Quantity))
+	}
+	return tx
+}
+
+// NewSpend debits amount of currency from the user.
+func NewSpend(userID, currency string, amount int64, reference string) (*LedgerTransaction, error) {
+	if strings.TrimSpace(currency) == "" || amount <= 0 || strings.TrimSpace(reference) == "" {
+		return nil, exceptions.ErrLedgerSpendInvalid
+	}
+	tx := &LedgerTransaction{
+		UserID:    userID,
+		Kind:      LedgerKindSpend,
+		Reference: reference,
+	}
+	tx.Entries = append(tx.Entries,
+		LedgerEntry{Account: userID, AssetKind: AssetCurrency, Asset: currency, Amount: -amount},
+		LedgerEntry{Account: LedgerAccountSpent, System: true, AssetKind: AssetCurrency, Asset: currency, Amount: amount},
+	)
+	return tx, nil
+}
+
+// issue moves amount of an asset from the rewards account to the user.
+func (t *LedgerTransaction) issue(kind AssetKind, asset string, amount int64) {
+	t.Entries = append(t.Entries,
+		LedgerEntry{Account: LedgerAccountRewards, System: true, AssetKind: kind, Asset: asset, Amount: -amount},
+		LedgerEntry{Account: t.UserID, AssetKind: kind, Asset: asset, Amount: amount},
+	)
+}
+
+// Balance is a user's holding of one asset: a currency balance or an item
+// quantity in the inventory.
+type Balance struct {
+	UserID    string
+	AssetKind AssetKind
+	Asset     string
+	Amount    int64
+	UpdatedAt time.Time
+}
@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// ProgressMark records that an event satisfied a step of a window or sequence
+// task at the time the event happened, which may differ from when it arrived.
+type ProgressMark struct {
+	Step       int
+	EventID    string
+	OccurredAt time.Time
+}
+
+// WindowCount returns the largest number of marks that fit in one window.
+func WindowCount(marks []ProgressMark, window time.Duration) int {
+	times := make([]time.Time, 0, len(marks))
+	for _, mark := range marks {
+		times = append(times, mark.OccurredAt)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	best := 0
+	start := 0
+	for end := range times {
+		for times[end].Sub(times[start]) > window {
+			start++
+		}
+		best = max(best, end-start+1)
+	}
+	return best
+}
+
+// SequenceProgress returns how many leading steps of the sequence were matched
+// in order by event time. With a window, every step must happen within window
+// of the first one. An event counts for at most one step of a run.
+func SequenceProgress(marks []ProgressMark, steps int, window time.Duration) int {
+	return len(SequenceRun(marks, steps, window))
+}
+
+// SequenceRun returns the marks of the longest run, in step order. Without a
+// window the run from the earliest first step is always a longest one, and
+// events that arrive in order can only extend that run.
+func SequenceRun(marks []ProgressMark, steps int, window time.Duration) []ProgressMark {
+	ordered := append([]ProgressMark(nil), marks...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].OccurredAt.Equal(ordered[j].OccurredAt) {
+			return ordered[i].OccurredAt.Before(ordered[j].OccurredAt)
+		}
+		return ordered[i].Step < ordered[j].Step
+	})
+
+	var best []ProgressMark
+	for i, first := range ordered {
+		if first.Step != 0 {
+			continue
+		}
+		run := []ProgressMark{first}
+		for _, mark := range ordered[i+1:] {
+			if len(run) == steps {
+				break
+			}
+			if window > 0 && mark.OccurredAt.Sub(first.OccurredAt) > window {
+				break
+			}
+			if mark.Step == len(run) && mark.EventID != run[len(run)-1].EventID {
+				run = append(run, mark)
+			}
+		}
+		if len(run) > len(best) {
+			best = run
+		}
+		if len(best) == steps || window == 0 {
+			break
+		}
+	}
+	return best
+}
+
+// SettledSequenceMarks returns the marks of a sequence without a window that
+// no later event can use, given that no event older than settled will arrive.
+// Settled marks always precede newer ones, and the greedy run over them from
+// the earliest first step reaches every step count as early as possible, so
+// any run continued by newer marks can start from it. The other settled marks
+// are returned; marks at or after settled are never returned.
+func SettledSequenceMarks(marks []ProgressMark, steps int, settled time.Time) []ProgressMark {
+	old := make([]ProgressMark, 0, len(marks))
+	for _, mark := range marks {
+		if mark.OccurredAt.Before(settled) {
+			old = append(old, mark)
+		}
+	}
+
+	type markKey struct {
+		eventID string
+		step    int
+	}
+	run := make(map[markKey]struct{}, steps)
+	for _, mark := range SequenceRun(old, steps, 0) {
+		run[markKey{mark.EventID, mark.Step}] = struct{}{}
+	}
+	unused := make([]ProgressMark, 0, len(old))
+	for _, mark := range old {
+		if _, ok := run[markKey{mark.EventID, mark.Step}]; !ok {
+			unused = append(unused, mark)
+		}
+	}
+	return unused
+}
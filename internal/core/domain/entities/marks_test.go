@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+var markBase = time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+func newMark(step int, eventID string, minutes int) ProgressMark {
+	return ProgressMark{Step: step, EventID: eventID, OccurredAt: markBase.Add(time.Duration(minutes) * time.Minute)}
+}
+
+func TestSequenceProgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		marks  []ProgressMark
+		steps  int
+		window time.Duration
+		want   int
+	}{
+		{name: "no marks", steps: 3, want: 0},
+		{name: "in order", marks: []ProgressMark{newMark(0, "a", 1), newMark(1, "b", 2), newMark(2, "c", 3)}, steps: 3, want: 3},
+		{name: "delivered out of order", marks: []ProgressMark{newMark(1, "b", 2), newMark(0, "a", 1), newMark(2, "c", 3)}, steps: 3, want: 3},
+		{name: "later step before any first step", marks: []ProgressMark{newMark(1, "b", 2)}, steps: 3, want: 0},
+		{name: "steps happened in the wrong order", marks: []ProgressMark{newMark(1, "b", 1), newMark(0, "a", 2), newMark(2, "c", 3)}, steps: 3, want: 1},
+		{name: "late first step completes the run", marks: []ProgressMark{newMark(2, "c", 3), newMark(1, "b", 2), newMark(0, "a", 1)}, steps: 3, want: 3},
+		{name: "one event counts for one step", marks: []ProgressMark{newMark(0, "a", 1), newMark(1, "a", 1), newMark(2, "c", 3)}, steps: 3, want: 1},
+		{name: "later start fits the window", marks: []ProgressMark{newMark(0, "a", 0), newMark(0, "d", 50), newMark(1, "b", 60), newMark(2, "c", 70)}, steps: 3, window: 30 * time.Minute, want: 3},
+		{name: "steps outside the window", marks: []ProgressMark{newMark(0, "a", 0), newMark(1, "b", 20), newMark(2, "c", 40)}, steps: 3, window: 30 * time.Minute, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SequenceProgress(tt.marks, tt.steps, tt.window); got != tt.want {
+				t.Errorf("SequenceProgress() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		marks  []ProgressMark
+		window time.Duration
+		want   int
+	}{
+		{name: "no marks", window: time.Hour, want: 0},
+		{name: "in order", marks: []ProgressMark{newMark(0, "a", 0), newMark(0, "b", 30), newMark(0, "c", 60)}, window: time.Hour, want: 3},
+		{name: "delivered out of order", marks: []ProgressMark{newMark(0, "c", 60), newMark(0, "a", 0), newMark(0, "b", 30)}, window: time.Hour, want: 3},
+		{name: "window edge is inclusive", marks: []ProgressMark{newMark(0, "b", 60), newMark(0, "a", 0)}, window: time.Hour, want: 2},
+		{name: "best window wins", marks: []ProgressMark{newMark(0, "d", 200), newMark(0, "a", 0), newMark(0, "c", 190), newMark(0, "b", 180)}, window: time.Hour, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WindowCount(tt.marks, tt.window); got != tt.want {
+				t.Errorf("WindowCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettledSequenceMarks(t *testing.T) {
+	tests := []struct {
+		name    string
+		marks   []ProgressMark
+		settled time.Time
+		want    []string
+	}{
+		{
+			name:    "nothing settled",
+			marks:   []ProgressMark{newMark(1, "b", 2), newMark(2, "c", 3)},
+			settled: markBase,
+		},
+		{
+			name:    "later step waiting for a late first step is kept",
+			marks:   []ProgressMark{newMark(1, "b", 2)},
+			settled: markBase.Add(time.Minute),
+		},
+		{
+			name:    "settled later step without a settled first step",
+			marks:   []ProgressMark{newMark(1, "b", 2), newMark(2, "c", 3)},
+			settled: markBase.Add(10 * time.Minute),
+			want:    []string{"b", "c"},
+		},
+		{
+			name:    "settled run is kept",
+			marks:   []ProgressMark{newMark(1, "b", 2), newMark(0, "a", 1)},
+			settled: markBase.Add(10 * time.Minute),
+		},
+		{
+			name:    "duplicates of settled steps are dropped",
+			marks:   []ProgressMark{newMark(0, "a", 1), newMark(0, "a2", 2), newMark(1, "b", 3), newMark(1, "b2", 4)},
+			settled: markBase.Add(10 * time.Minute),
+			want:    []string{"a2", "b2"},
+		},
+		{
+			name:    "marks after settled are kept",
+			marks:   []ProgressMark{newMark(0, "a", 1), newMark(0, "a2", 20), newMark(1, "b", 30)},
+			settled: markBase.Add(10 * time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SettledSequenceMarks(tt.marks, 3, tt.settled)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SettledSequenceMarks() = %v, want events %v", got, tt.want)
+			}
+			for i, mark := range got {
+				if mark.EventID != tt.want[i] {
+					t.Errorf("SettledSequenceMarks()[%d] = %q, want %q", i, mark.EventID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// Pruning settled marks must not change the progress later events reach.
+func TestSettledSequenceMarksKeepProgress(t *testing.T) {
+	marks := []ProgressMark{newMark(0, "a", 1), newMark(1, "x", 2), newMark(0, "a2", 3), newMark(1, "b", 4), newMark(2, "y", 5)}
+	settled := markBase.Add(10 * time.Minute)
+	late := []ProgressMark{newMark(2, "c", 11), newMark(1, "b3", 12), newMark(2, "c2", 13)}
+
+	dropped := make(map[string]bool)
+	for _, mark := range SettledSequenceMarks(marks, 4, settled) {
+		dropped[mark.EventID] = true
+	}
+	kept := make([]ProgressMark, 0, len(marks))
+	for _, mark := range marks {
+		if !dropped[mark.EventID] {
+			kept = append(kept, mark)
+		}
+	}
+
+	for i := range late {
+		all := append(append([]ProgressMark(nil), marks...), late[:i+1]...)
+		pruned := append(append([]ProgressMark(nil), kept...), late[:i+1]...)
+		if got, want := SequenceProgress(pruned, 4, 0), SequenceProgress(all, 4, 0); got != want {
+			t.Errorf("after %d late marks progress = %d, want %d", i+1, got, want)
+		}
+	}
+}
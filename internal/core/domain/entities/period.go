@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+type ResetPeriod string
+
+const (
+	ResetPeriodNone   ResetPeriod = ""
+	ResetPeriodDaily  ResetPeriod = "daily"
+	ResetPeriodWeekly ResetPeriod = "weekly"
+)
+
+func (p ResetPeriod) IsValid() bool {
+	switch p {
+	case ResetPeriodNone, ResetPeriodDaily, ResetPeriodWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+// PeriodKey returns the key of the period containing at, e.g. "2026-01-23" for
+// daily and "2026-W04" (ISO week) for weekly. Non-resetting tasks use an empty key.
+func PeriodKey(period ResetPeriod, at time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := at.In(loc)
+	switch period {
+	case ResetPeriodDaily:
+		return local.Format(time.DateOnly)
+	case ResetPeriodWeekly:
+		year, week := local.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	default:
+		return ""
+	}
+}
+
+// PeriodEnd returns the instant the period containing at ends in loc, or zero
+// time for tasks that never reset.
+func PeriodEnd(period ResetPeriod, at time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := at.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	switch period {
+	case ResetPeriodDaily:
+		return dayStart.AddDate(0, 0, 1)
+	case ResetPeriodWeekly:
+		daysSinceMonday := (int(local.Weekday()) + 6) % 7
+		return dayStart.AddDate(0, 0, 7-daysSinceMonday)
+	default:
+		return time.Time{}
+	}
+}
+
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, exceptions.ErrTimezoneInvalid
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, exceptions.ErrTimezoneInvalid
+	}
+	return loc, nil
+}
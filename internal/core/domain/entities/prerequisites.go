@@ -0,0 +1,52 @@
+package entities
+
+import "task-manager/internal/core/domain/exceptions"
+
+// CheckPrerequisiteGraph verifies that every prerequisite refers to a known task
+// and that the graph (task ID -> prerequisite IDs) has no cycles.
+func CheckPrerequisiteGraph(graph map[string][]string) error {
+	return checkTaskGraph(graph, exceptions.ErrTaskPrerequisiteNotFound, exceptions.ErrTaskPrerequisiteCycle)
+}
+
+// CheckQuestGraph verifies that every quest child refers to a known task and
+// that quests (quest ID -> child IDs) do not contain themselves.
+func CheckQuestGraph(graph map[string][]string) error {
+	return checkTaskGraph(graph, exceptions.ErrTaskQuestChildNotFound, exceptions.ErrTaskQuestCycle)
+}
+
+func checkTaskGraph(graph map[string][]string, errNotFound, errCycle error) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(graph))
+	var visit func(taskID string) error
+	visit = func(taskID string) error {
+		switch state[taskID] {
+		case visiting:
+			return errCycle
+		case visited:
+			return nil
+		}
+		state[taskID] = visiting
+		for _, edgeID := range graph[taskID] {
+			if _, ok := graph[edgeID]; !ok {
+				return errNotFound
+			}
+			if err := visit(edgeID); err != nil {
+				return err
+			}
+		}
+		state[taskID] = visited
+		return nil
+	}
+
+	for taskID := range graph {
+		if err := visit(taskID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -42,24 +42,41 @@ type TaskProgress struct {
 	id        string
 	taskID    string
 	userID    string
+	periodKey string
 	progress  int
 	completed bool
 	claimed   bool
 	updatedAt time.Time
+
+	claimedTiers []int
+	tierStates   []TierState
+	completions  int
+	claims       int
+
+	periodEndsAt time.Time
+	locked       bool
+
+	streakCurrent int
+	streakLongest int
+
+	roomID       string
+	contribution int
 }
 
-func NewTaskProgress(taskID, userID string) *TaskProgress {
+func NewTaskProgress(taskID, userID, periodKey string) *TaskProgress {
 	return &TaskProgress{
-		taskID: taskID,
-		userID: userID,
+		taskID:    taskID,
+		userID:    userID,
+		periodKey: periodKey,
 	}
 }
 
-func NewTaskProgressFromData(id, taskID, userID string, progress int, completed, claimed bool, updatedAt time.Time) *TaskProgress {
+func NewTaskProgressFromData(id, taskID, userID, periodKey string, progress int, completed, claimed bool, updatedAt time.Time) *TaskProgress {
 	return &TaskProgress{
 		id:        id,
 		taskID:    taskID,
 		userID:    userID,
+		periodKey: periodKey,
 		progress:  progress,
 		completed: completed,
 		claimed:   claimed,
@@ -79,6 +96,10 @@ func (p *TaskProgress) UserID() string {
 	return p.userID
 }
 
+func (p *TaskProgress) PeriodKey() string {
+	return p.periodKey
+}
+
 func (p *TaskProgress) Progress() int {
 	return p.progress
 }
@@ -91,10 +112,102 @@ func (p *TaskProgress) Claimed() bool {
 	return p.claimed
 }
 
+// Completions counts finished repetitions in the current period.
+func (p *TaskProgress) Completions() int {
+	return p.completions
+}
+
+// Claims counts repetitions whose reward was claimed in the current period.
+func (p *TaskProgress) Claims() int {
+	return p.claims
+}
+
+func (p *TaskProgress) SetCounters(completions, claims int) {
+	p.completions = completions
+	p.claims = claims
+}
+
+// ClaimedTiers lists the 1-based tier numbers whose reward was claimed.
+func (p *TaskProgress) ClaimedTiers() []int {
+	if len(p.claimedTiers) == 0 {
+		return nil
+	}
+	return append([]int(nil), p.claimedTiers...)
+}
+
+func (p *TaskProgress) IsTierClaimed(number int) bool {
+	for _, claimed := range p.claimedTiers {
+		if claimed == number {
+			return true
+		}
+	}
+	return false
+}
+
+// NextClaimableTier returns the lowest completed tier that is not claimed yet.
+func (p *TaskProgress) NextClaimableTier(tiers []TaskTier) (int, error) {
+	allClaimed := true
+	for i, tier := range tiers {
+		number := i + 1
+		if p.IsTierClaimed(number) {
+			continue
+		}
+		allClaimed = false
+		if p.progress >= tier.Target {
+			return number, nil
+		}
+	}
+	if allClaimed {
+		return 0, exceptions.ErrRewardAlreadyClaimed
+	}
+	return 0, exceptions.ErrTaskNotCompleted
+}
+
+// TierStates returns per-tier completed/claimed flags resolved by ResolveTiers.
+func (p *TaskProgress) TierStates() []TierState {
+	return append([]TierState(nil), p.tierStates...)
+}
+
+func (p *TaskProgress) ResolveTiers(tiers []TaskTier) {
+	p.tierStates = make([]TierState, 0, len(tiers))
+	for i, tier := range tiers {
+		number := i + 1
+		p.tierStates = append(p.tierStates, TierState{
+			Tier:      number,
+			Target:    tier.Target,
+			Completed: p.progress >= tier.Target,
+			Claimed:   p.IsTierClaimed(number),
+		})
+	}
+}
+
+func (p *TaskProgress) SetClaimedTiers(tiers []int) {
+	p.claimedTiers = append([]int(nil), tiers...)
+}
+
 func (p *TaskProgress) UpdatedAt() time.Time {
 	return p.updatedAt
 }
 
+// PeriodEndsAt is when the current period of a resetting task ends for the user.
+// It is derived from the user's timezone and is not persisted.
+func (p *TaskProgress) PeriodEndsAt() time.Time {
+	return p.periodEndsAt
+}
+
+func (p *TaskProgress) SetPeriodEndsAt(at time.Time) {
+	p.periodEndsAt = at
+}
+
+// Locked reports whether the task's prerequisites are not yet claimed by the user.
+func (p *TaskProgress) Locked() bool {
+	return p.locked
+}
+
+func (p *TaskProgress) SetLocked(locked bool) {
+	p.locked = locked
+}
+
 func (p *TaskProgress) SetID(id string) {
 	p.id = id
 }
@@ -102,3 +215,32 @@ func (p *TaskProgress) SetID(id string) {
 func (p *TaskProgress) SetUpdatedAt(at time.Time) {
 	p.updatedAt = at
 }
+
+// StreakCurrent is the live streak of a streak task, 0 once it is broken.
+func (p *TaskProgress) StreakCurrent() int {
+	return p.streakCurrent
+}
+
+func (p *TaskProgress) StreakLongest() int {
+	return p.streakLongest
+}
+
+func (p *TaskProgress) SetStreak(current, longest int) {
+	p.streakCurrent = current
+	p.streakLongest = longest
+}
+
+// SetRoom marks the progress as the shared progress of a room, along with what
+// the user contributed to it.
+func (p *TaskProgress) SetRoom(roomID string, contribution int) {
+	p.roomID = roomID
+	p.contribution = contribution
+}
+
+func (p *TaskProgress) RoomID() string {
+	return p.roomID
+}
+
+func (p *TaskProgress) Contribution() int {
+	return p.contribution
+}
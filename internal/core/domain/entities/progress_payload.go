@@ -11,9 +11,6 @@ func (p *ProgressPayload) Validate() error {
 	if p == nil {
 		return exceptions.ErrEventPayloadInvalid
 	}
-	if p.TaskID == "" {
-		return exceptions.ErrEventTaskIDRequired
-	}
 	if p.Amount <= 0 {
 		return exceptions.ErrEventAmountInvalid
 	}
@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+// Reward is what a claim grants. Rewards are stored as their JSON encoding, for
+// example {"currencies":[{"currency":"gold","amount":100}],"xp":50}.
+type Reward struct {
+	Currencies []CurrencyReward `json:"currencies,omitempty"`
+	Items      []ItemReward     `json:"items,omitempty"`
+	XP         int64            `json:"xp,omitempty"`
+	Bundles    []BundleReward   `json:"bundles,omitempty"`
+}
+
+type CurrencyReward struct {
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+type ItemReward struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+// BundleReward grants a catalog bundle, which the game expands into its contents.
+type BundleReward struct {
+	BundleID string `json:"bundle_id"`
+	Quantity int    `json:"quantity"`
+}
+
+// ParseReward decodes a stored reward. Unknown fields are rejected so typos in
+// reward definitions fail when the reward is saved rather than when a player
+// claims it. Empty input is no reward.
+func ParseReward(raw json.RawMessage) (*Reward, error) {
+	if len(bytes.TrimSpace(raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return nil, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var reward Reward
+	if err := decoder.Decode(&reward); err != nil {
+		return nil, exceptions.ErrTaskRewardInvalid
+	}
+	if decoder.More() {
+		return nil, exceptions.ErrTaskRewardInvalid
+	}
+	if err := reward.Validate(); err != nil {
+		return nil, err
+	}
+	return &reward, nil
+}
+
+// EncodeReward returns the stored form of the reward, or nil for no reward.
+func EncodeReward(reward *Reward) json.RawMessage {
+	if reward.IsEmpty() {
+		return nil
+	}
+	// A Reward holds only strings and integers, which always encode.
+	raw, _ := json.Marshal(reward)
+	return raw
+}
+
+func (r *Reward) IsEmpty() bool {
+	return r == nil || (len(r.Currencies) == 0 && len(r.Items) == 0 && r.XP == 0 && len(r.Bundles) == 0)
+}
+
+// LedgerOnly reports whether the ledger can credit the whole reward: it holds
+// currencies and items but no XP or bundles.
+func (r *Reward) LedgerOnly() bool {
+	return r == nil || (r.XP == 0 && len(r.Bundles) == 0)
+}
+
+func (r *Reward) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.XP < 0 {
+		return exceptions.ErrTaskRewardInvalid
+	}
+	currencies := make(map[string]struct{}, len(r.Currencies))
+	for _, currency := range r.Currencies {
+		if strings.TrimSpace(currency.Currency) == "" || currency.Amount <= 0 {
+			return exceptions.ErrTaskRewardInvalid
+		}
+		if _, ok := currencies[currency.Currency]; ok {
+			return exceptions.ErrTaskRewardInvalid
+		}
+		currencies[currency.Currency] = struct{}{}
+	}
+	for _, item := range r.Items {
+		if strings.TrimSpace(item.ItemID) == "" || item.Quantity <= 0 {
+			return exceptions.ErrTaskRewardInvalid
+		}
+	}
+	for _, bundle := range r.Bundles {
+		if strings.TrimSpace(bundle.BundleID) == "" || bundle.Quantity <= 0 {
+			return exceptions.ErrTaskRewardInvalid
+		}
+	}
+	return nil
+}
+
+func isValidReward(reward json.RawMessage) bool {
+	_, err := ParseReward(reward)
+	return err == nil
+}
+
+// MergeRewards sums rewards into one, adding up amounts of the same currency,
+// item and bundle in order of first appearance. Nil rewards are skipped.
+func MergeRewards(rewards ...*Reward) *Reward {
+	merged := &Reward{}
+	currencies := make(map[string]int)
+	items := make(map[string]int)
+	bundles := make(map[string]int)
+	for _, reward := range rewards {
+		if reward == nil {
+			continue
+		}
+		merged.XP += reward.XP
+		for _, currency := range reward.Currencies {
+			if i, ok := currencies[currency.Currency]; ok {
+				merged.Currencies[i].Amount += currency.Amount
+				continue
+			}
+			currencies[currency.Currency] = len(merged.Currencies)
+			merged.Currencies = append(merged.Currencies, currency)
+		}
+		for _, item := range reward.Items {
+			if i, ok := items[item.ItemID]; ok {
+				merged.Items[i].Quantity += item.Quantity
+				continue
+			}
+			items[item.ItemID] = len(merged.Items)
+			merged.Items = append(merged.Items, item)
+		}
+		for _, bundle := range reward.Bundles {
+			if i, ok := bundles[bundle.BundleID]; ok {
+				merged.Bundles[i].Quantity += bundle.Quantity
+				continue
+			}
+			bundles[bundle.BundleID] = len(merged.Bundles)
+			merged.Bundles = append(merged.Bundles, bundle)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RewardClaim is the outcome of a successful claim. Claims made with an
+// idempotency key are stored so that a retry returns the original outcome
+// instead of failing as already claimed.
+type RewardClaim struct {
+	IdempotencyKey string
+	UserID         string
+	TaskID         string
+	PeriodKey      string
+	// Tier is 0 for repeatable and shared tasks, which have a single reward.
+	Tier int
+	// RoomID is the room or group of a shared task.
+	RoomID string
+	Reward json.RawMessage
+	// GrantID is the outbox grant of the reward; empty for claims without one.
+	GrantID   string
+	ClaimedAt time.Time
+	// Replayed is set when the claim was returned for a retried request.
+	Replayed bool
+}
+
+// Grant returns the outbox grant that delivers the claimed reward.
+func (c *RewardClaim) Grant() *RewardGrant {
+	return NewRewardGrant(c.UserID, c.TaskID, c.PeriodKey, c.Tier, c.Reward)
+}
+
+// TaskClaims is everything claimed on one task in a single request, with the
+// progress left after the claims.
+type TaskClaims struct {
+	TaskID   string
+	Claims   []*RewardClaim
+	Progress *TaskProgress
+}
+
+// Reward returns the combined reward of the claims. Rewards that predate the
+// reward schema are left out.
+func (c TaskClaims) Reward() *Reward {
+	rewards := make([]*Reward, 0, len(c.Claims))
+	for _, claim := range c.Claims {
+		reward, _ := ParseReward(claim.Reward)
+		rewards = append(rewards, reward)
+	}
+	return MergeRewards(rewards...)
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RewardDeliveryStatus string
+
+const (
+	RewardDeliveryPending   RewardDeliveryStatus = "pending"
+	RewardDeliveryDelivered RewardDeliveryStatus = "delivered"
+	RewardDeliveryFailed    RewardDeliveryStatus = "failed"
+)
+
+// RewardSource is what a grant rewards the user for.
+type RewardSource string
+
+const (
+	RewardSourceTask        RewardSource = "task"
+	RewardSourceSeason      RewardSource = "season"
+	RewardSourceAchievement RewardSource = "achievement"
+)
+
+// RewardGrant is a claimed reward waiting in the outbox to be delivered to the
+// user. Its ID doubles as the idempotency key sinks use to drop redeliveries.
+// SourceID is the task, season or achievement ID; TaskID is only set for task
+// grants.
+// Task grants carry the claimed period and tier, which is 0 for repeatable and
+// shared tasks. Season grants carry the level as the tier and the track as the
+// period key; achievement grants carry neither.
+type RewardGrant struct {
+	ID            string
+	UserID        string
+	Source        RewardSource
+	SourceID      string
+	TaskID        string
+	PeriodKey     string
+	Tier          int
+	Reward        json.RawMessage
+	Status        RewardDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	DeliveredAt   time.Time
+}
+
+func NewRewardGrant(userID, taskID, periodKey string, tier int, reward json.RawMessage) *RewardGrant {
+	return &RewardGrant{
+		UserID:    userID,
+		Source:    RewardSourceTask,
+		SourceID:  taskID,
+		TaskID:    taskID,
+		PeriodKey: periodKey,
+		Tier:      tier,
+		Reward:    append(json.RawMessage(nil), reward...),
+		Status:    RewardDeliveryPending,
+	}
+}
+
+// NewSeasonRewardGrant grants the reward of a season level on one track.
+func NewSeasonRewardGrant(userID, seasonID string, level int, track SeasonTrack, reward json.RawMessage) *RewardGrant {
+	return &RewardGrant{
+		UserID:    userID,
+		Source:    RewardSourceSeason,
+		SourceID:  seasonID,
+		PeriodKey: string(track),
+		Tier:      level,
+		Reward:    append(json.RawMessage(nil), reward...),
+		Status:    RewardDeliveryPending,
+	}
+}
+
+// NewAchievementRewardGrant grants the reward of an unlocked achievement.
+func NewAchievementRewardGrant(userID, achievementID string, reward json.RawMessage) *RewardGrant {
+	return &RewardGrant{
+		UserID:   userID,
+		Source:   RewardSourceAchievement,
+		SourceID: achievementID,
+		Reward:   append(json.RawMessage(nil), reward...),
+		Status:   RewardDeliveryPending,
+	}
+}
+
+// MarkDelivered records a successful delivery.
+func (g *RewardGrant) MarkDelivered(at time.Time) {
+	g.Status = RewardDeliveryDelivered
+	g.Attempts++
+	g.LastError = ""
+	g.DeliveredAt = at
+}
+
+// MarkAttemptFailed records a failed delivery and schedules the next attempt
+// at retryAt. Once maxAttempts are used up the grant is marked failed and is
+// no longer retried.
+func (g *RewardGrant) MarkAttemptFailed(reason string, retryAt time.Time, maxAttempts int) {
+	g.Attempts++
+	g.LastError = reason
+	if g.Attempts >= maxAttempts {
+		g.Status = RewardDeliveryFailed
+		return
+	}
+	g.NextAttemptAt = retryAt
+}
@@ -0,0 +1,66 @@
+package entities
+
+import "time"
+
+type TaskScope string
+
+const (
+	// TaskScopeUser tracks progress for each user on their own.
+	TaskScopeUser TaskScope = "user"
+	// TaskScopeRoom adds the progress of everyone in a room to one counter.
+	TaskScopeRoom TaskScope = "room"
+	// TaskScopeGroup adds the progress of a group's members to one counter.
+	// Progress counts for the group the user belonged to when it was made.
+	TaskScopeGroup TaskScope = "group"
+)
+
+func (s TaskScope) IsValid() bool {
+	switch s {
+	case TaskScopeUser, TaskScopeRoom, TaskScopeGroup:
+		return true
+	default:
+		return false
+	}
+}
+
+// RoomContribution is what one member added to a room or group task.
+type RoomContribution struct {
+	UserID    string
+	Amount    int
+	Claimed   bool
+	UpdatedAt time.Time
+}
+
+// RoomProgress is the shared progress of a room or group task in one period;
+// for group tasks RoomID holds the group ID. Shared periods follow the service
+// period timezone, since members may live in different timezones.
+type RoomProgress struct {
+	TaskID        string
+	RoomID        string
+	PeriodKey     string
+	Progress      int
+	Target        int
+	Completed     bool
+	CompletedAt   time.Time
+	UpdatedAt     time.Time
+	Contributions []RoomContribution
+}
+
+// Contribution returns the member's contribution, if they made one.
+func (p *RoomProgress) Contribution(userID string) (RoomContribution, bool) {
+	for _, contribution := range p.Contributions {
+		if contribution.UserID == userID {
+			return contribution, true
+		}
+	}
+	return RoomContribution{}, false
+}
+
+// UserProgress presents the shared progress from the member's point of view:
+// the shared counter and completion with the member's own claim state.
+func (p *RoomProgress) UserProgress(userID string) *TaskProgress {
+	contribution, _ := p.Contribution(userID)
+	progress := NewTaskProgressFromData("", p.TaskID, userID, p.PeriodKey, p.Progress, p.Completed, contribution.Claimed, p.UpdatedAt)
+	progress.SetRoom(p.RoomID, contribution.Amount)
+	return progress
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/core/domain/expression"
+)
+
+// TaskRule describes which events advance a task when the event does not name
+// the task explicitly. An event matches when its type is listed and every
+// filter equals the text form of the event attribute with the same key.
+// Condition is an optional expression over event attributes, for example
+// `score >= 1000 && mode == "ranked"`, that must also hold.
+type TaskRule struct {
+	EventTypes []TaskEventType   `json:"event_types"`
+	Filters    map[string]string `json:"filters,omitempty"`
+	Condition  string            `json:"condition,omitempty"`
+}
+
+func (r *TaskRule) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if len(r.EventTypes) == 0 {
+		return exceptions.ErrTaskRuleInvalid
+	}
+	for _, eventType := range r.EventTypes {
+		if strings.TrimSpace(string(eventType)) == "" {
+			return exceptions.ErrTaskRuleInvalid
+		}
+	}
+	for key := range r.Filters {
+		if strings.TrimSpace(key) == "" {
+			return exceptions.ErrTaskRuleInvalid
+		}
+	}
+	if r.Condition != "" {
+		if _, err := expression.Compile(r.Condition); err != nil {
+			return fmt.Errorf("%w: %v", exceptions.ErrTaskConditionInvalid, err)
+		}
+	}
+	return nil
+}
+
+func (r *TaskRule) Matches(event *TaskEvent) bool {
+	if r == nil || event == nil {
+		return false
+	}
+	typeMatched := false
+	for _, eventType := range r.EventTypes {
+		if eventType == event.Type() {
+			typeMatched = true
+			break
+		}
+	}
+	if !typeMatched {
+		return false
+	}
+
+	attributes := event.Attributes()
+	for key, expected := range r.Filters {
+		if value, ok := attributes.Text(key); !ok || value != expected {
+			return false
+		}
+	}
+	return r.ConditionHolds(event)
+}
+
+// ConditionHolds evaluates the rule condition against the event attributes.
+// Rules without a condition always hold; a condition that no longer compiles never does.
+func (r *TaskRule) ConditionHolds(event *TaskEvent) bool {
+	if r == nil || r.Condition == "" {
+		return true
+	}
+	program, err := expression.Compile(r.Condition)
+	if err != nil {
+		return false
+	}
+	return program.Eval(event.Attributes())
+}
+
+func (r *TaskRule) clone() *TaskRule {
+	if r == nil {
+		return nil
+	}
+	clone := &TaskRule{
+		EventTypes: append([]TaskEventType(nil), r.EventTypes...),
+		Condition:  r.Condition,
+	}
+	if len(r.Filters) > 0 {
+		clone.Filters = make(map[string]string, len(r.Filters))
+		for key, value := range r.Filters {
+			clone.Filters[key] = value
+		}
+	}
+	return clone
+}
@@ -0,0 +1,219 @@
+package entities
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+type SeasonTrack string
+
+const (
+	SeasonTrackFree    SeasonTrack = "free"
+	SeasonTrackPremium SeasonTrack = "premium"
+)
+
+func (t SeasonTrack) IsValid() bool {
+	return t == SeasonTrackFree || t == SeasonTrackPremium
+}
+
+// SeasonLevel is reached once the user has XP season XP. Levels are numbered
+// from 1 in ascending XP order and carry one reward per track.
+type SeasonLevel struct {
+	XP            int             `json:"xp"`
+	FreeReward    json.RawMessage `json:"free_reward,omitempty"`
+	PremiumReward json.RawMessage `json:"premium_reward,omitempty"`
+}
+
+// Reward returns the level reward on the given track.
+func (l SeasonLevel) Reward(track SeasonTrack) json.RawMessage {
+	if track == SeasonTrackPremium {
+		return l.PremiumReward
+	}
+	return l.FreeReward
+}
+
+// Season is a time-boxed progression that users advance with XP earned by
+// claiming task rewards.
+type Season struct {
+	id        string
+	name      string
+	startsAt  time.Time
+	endsAt    time.Time
+	levels    []SeasonLevel
+	createdAt time.Time
+}
+
+func NewSeason(id, name string, startsAt, endsAt time.Time, levels []SeasonLevel, createdAt time.Time) *Season {
+	season := &Season{
+		id:        id,
+		name:      name,
+		startsAt:  startsAt,
+		endsAt:    endsAt,
+		createdAt: createdAt,
+	}
+	season.levels = cloneLevels(levels)
+	return season
+}
+
+func (s *Season) ID() string {
+	return s.id
+}
+
+func (s *Season) Name() string {
+	return s.name
+}
+
+func (s *Season) StartsAt() time.Time {
+	return s.startsAt
+}
+
+func (s *Season) EndsAt() time.Time {
+	return s.endsAt
+}
+
+func (s *Season) Levels() []SeasonLevel {
+	return cloneLevels(s.levels)
+}
+
+func (s *Season) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Season) SetID(id string) {
+	s.id = id
+}
+
+func (s *Season) SetCreatedAt(createdAt time.Time) {
+	s.createdAt = createdAt
+}
+
+// Level returns the level with the given 1-based number.
+func (s *Season) Level(number int) (SeasonLevel, error) {
+	if number < 1 || number > len(s.levels) {
+		return SeasonLevel{}, exceptions.ErrSeasonLevelInvalid
+	}
+	return s.levels[number-1], nil
+}
+
+// LevelAt returns the highest level reached with xp, 0 before the first one.
+func (s *Season) LevelAt(xp int) int {
+	reached := 0
+	for i, level := range s.levels {
+		if xp < level.XP {
+			break
+		}
+		reached = i + 1
+	}
+	return reached
+}
+
+func (s *Season) IsActiveAt(at time.Time) bool {
+	return !at.Before(s.startsAt) && at.Before(s.endsAt)
+}
+
+func (s *Season) Validate() error {
+	if strings.TrimSpace(s.name) == "" {
+		return exceptions.ErrSeasonInvalid
+	}
+	if s.startsAt.IsZero() || s.endsAt.IsZero() || !s.endsAt.After(s.startsAt) {
+		return exceptions.ErrSeasonInvalid
+	}
+	if len(s.levels) == 0 {
+		return exceptions.ErrSeasonInvalid
+	}
+	previous := 0
+	for _, level := range s.levels {
+		if level.XP <= previous {
+			return exceptions.ErrSeasonInvalid
+		}
+		if !isValidReward(level.FreeReward) || !isValidReward(level.PremiumReward) {
+			return exceptions.ErrTaskRewardInvalid
+		}
+		previous = level.XP
+	}
+	return nil
+}
+
+// SeasonProgress is a user's XP, pass and claimed level rewards in a season.
+type SeasonProgress struct {
+	userID         string
+	seasonID       string
+	xp             int
+	premium        bool
+	claimedFree    []int
+	claimedPremium []int
+	updatedAt      time.Time
+}
+
+func NewSeasonProgress(userID, seasonID string) *SeasonProgress {
+	return &SeasonProgress{
+		userID:   userID,
+		seasonID: seasonID,
+	}
+}
+
+func NewSeasonProgressFromData(userID, seasonID string, xp int, premium bool, claimedFree, claimedPremium []int, updatedAt time.Time) *SeasonProgress {
+	return &SeasonProgress{
+		userID:         userID,
+		seasonID:       seasonID,
+		xp:             xp,
+		premium:        premium,
+		claimedFree:    slices.Clone(claimedFree),
+		claimedPremium: slices.Clone(claimedPremium),
+		updatedAt:      updatedAt,
+	}
+}
+
+func (p *SeasonProgress) UserID() string {
+	return p.userID
+}
+
+func (p *SeasonProgress) SeasonID() string {
+	return p.seasonID
+}
+
+func (p *SeasonProgress) XP() int {
+	return p.xp
+}
+
+func (p *SeasonProgress) Premium() bool {
+	return p.premium
+}
+
+// ClaimedLevels returns the level numbers claimed on the track.
+func (p *SeasonProgress) ClaimedLevels(track SeasonTrack) []int {
+	if track == SeasonTrackPremium {
+		return slices.Clone(p.claimedPremium)
+	}
+	return slices.Clone(p.claimedFree)
+}
+
+func (p *SeasonProgress) IsLevelClaimed(level int, track SeasonTrack) bool {
+	if track == SeasonTrackPremium {
+		return slices.Contains(p.claimedPremium, level)
+	}
+	return slices.Contains(p.claimedFree, level)
+}
+
+func (p *SeasonProgress) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+func cloneLevels(levels []SeasonLevel) []SeasonLevel {
+	if len(levels) == 0 {
+		return nil
+	}
+	clone := make([]SeasonLevel, 0, len(levels))
+	for _, level := range levels {
+		clone = append(clone, SeasonLevel{
+			XP:            level.XP,
+			FreeReward:    append(json.RawMessage(nil), level.FreeReward...),
+			PremiumReward: append(json.RawMessage(nil), level.PremiumReward...),
+		})
+	}
+	return clone
+}
@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// SessionKeyAttribute is the optional event attribute that tells apart
+// concurrent sessions of one user, e.g. two games played at once.
+const SessionKeyAttribute = "session_id"
+
+// Session is an open play session awaiting its stop event.
+type Session struct {
+	UserID     string
+	Key        string
+	StartedAt  time.Time
+	Attributes EventAttributes
+}
+
+// SessionKey returns the key pairing the event's start and stop.
+func SessionKey(event *TaskEvent) string {
+	key, _ := event.Attributes().Text(SessionKeyAttribute)
+	return key
+}
+
+// SessionMinutes returns the whole minutes between start and stop, capped at
+// maxLength so a stop that never arrived cannot credit unbounded time.
+func SessionMinutes(startedAt, stoppedAt time.Time, maxLength time.Duration) int {
+	elapsed := stoppedAt.Sub(startedAt)
+	if elapsed < 0 {
+		return 0
+	}
+	if maxLength > 0 && elapsed > maxLength {
+		elapsed = maxLength
+	}
+	return int(elapsed / time.Minute)
+}
@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+const maxStreakGraceDays = 7
+
+// StreakConfig turns a task into a streak over the listed daily tasks: every
+// day on which the user completes any of them extends the streak. GraceDays
+// is how many missed days in a row are forgiven.
+type StreakConfig struct {
+	TaskIDs   []string `json:"task_ids"`
+	GraceDays int      `json:"grace_days,omitempty"`
+}
+
+func (c *StreakConfig) Validate(streakID string) error {
+	if c == nil {
+		return nil
+	}
+	if len(c.TaskIDs) == 0 || c.GraceDays < 0 || c.GraceDays > maxStreakGraceDays {
+		return exceptions.ErrTaskStreakInvalid
+	}
+	seen := make(map[string]struct{}, len(c.TaskIDs))
+	for _, taskID := range c.TaskIDs {
+		if taskID == "" || taskID == streakID {
+			return exceptions.ErrTaskStreakInvalid
+		}
+		if _, ok := seen[taskID]; ok {
+			return exceptions.ErrTaskStreakInvalid
+		}
+		seen[taskID] = struct{}{}
+	}
+	return nil
+}
+
+func (c *StreakConfig) clone() *StreakConfig {
+	if c == nil {
+		return nil
+	}
+	return &StreakConfig{
+		TaskIDs:   slices.Clone(c.TaskIDs),
+		GraceDays: c.GraceDays,
+	}
+}
+
+// Streak is a user's run of consecutive days for a streak task. Days are
+// daily period keys ("2006-01-02") in the user's timezone.
+type Streak struct {
+	userID    string
+	taskID    string
+	current   int
+	longest   int
+	lastDay   string
+	updatedAt time.Time
+}
+
+func NewStreak(userID, taskID string) *Streak {
+	return &Streak{
+		userID: userID,
+		taskID: taskID,
+	}
+}
+
+func NewStreakFromData(userID, taskID string, current, longest int, lastDay string, updatedAt time.Time) *Streak {
+	return &Streak{
+		userID:    userID,
+		taskID:    taskID,
+		current:   current,
+		longest:   longest,
+		lastDay:   lastDay,
+		updatedAt: updatedAt,
+	}
+}
+
+func (s *Streak) UserID() string {
+	return s.userID
+}
+
+func (s *Streak) TaskID() string {
+	return s.taskID
+}
+
+// Current is the streak length as of the last recorded day; use CurrentAt for
+// a value that accounts for days missed since.
+func (s *Streak) Current() int {
+	return s.current
+}
+
+func (s *Streak) Longest() int {
+	return s.longest
+}
+
+func (s *Streak) LastDay() string {
+	return s.lastDay
+}
+
+func (s *Streak) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// CurrentAt returns the streak still alive on day, or 0 once more than
+// graceDays days were missed after the last recorded day.
+func (s *Streak) CurrentAt(day string, graceDays int) int {
+	if s.lastDay == "" {
+		return 0
+	}
+	gap, ok := daysBetween(s.lastDay, day)
+	if !ok || gap > graceDays+1 {
+		return 0
+	}
+	return s.current
+}
+
+// Record counts day towards the streak and reports whether the streak changed.
+// Days already counted or earlier than the last recorded day are ignored.
+func (s *Streak) Record(day string, graceDays int, at time.Time) bool {
+	if s.lastDay != "" {
+		gap, ok := daysBetween(s.lastDay, day)
+		if !ok || gap <= 0 {
+			return false
+		}
+		if gap > graceDays+1 {
+			s.current = 0
+		}
+	}
+	s.current++
+	s.longest = max(s.longest, s.current)
+	s.lastDay = day
+	s.updatedAt = at
+	return true
+}
+
+func daysBetween(from, to string) (int, bool) {
+	fromDay, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return 0, false
+	}
+	toDay, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return 0, false
+	}
+	return int(toDay.Sub(fromDay).Hours() / 24), true
+}
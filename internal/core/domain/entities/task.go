@@ -2,7 +2,11 @@ package entities
 
 import (
 	"encoding/json"
+	"slices"
+	"strings"
 	"time"
+
+	"task-manager/internal/core/domain/exceptions"
 )
 
 type TaskType string
@@ -27,7 +31,22 @@ type Task struct {
 	taskType    TaskType
 	target      int
 	reward      json.RawMessage
+	tiers       []TaskTier
+	repeatLimit int
+	rule        *TaskRule
+	aggregation Aggregation
+	seasonXP    int
 	isActive    bool
+	resetPeriod ResetPeriod
+	prereqs     []string
+	children    []string
+	questIDs    []string
+	streak      *StreakConfig
+	streakIDs   []string
+	scope       TaskScope
+	leaderboard *LeaderboardConfig
+	startsAt    time.Time
+	endsAt      time.Time
 	createdAt   time.Time
 }
 
@@ -68,6 +87,8 @@ func (t *Task) Target() int {
 	return t.target
 }
 
+// Reward returns the stored reward JSON. Clients should prefer TypedReward;
+// the raw form is kept for rewards saved before the reward schema existed.
 func (t *Task) Reward() json.RawMessage {
 	if len(t.reward) == 0 {
 		return nil
@@ -75,10 +96,459 @@ func (t *Task) Reward() json.RawMessage {
 	return append(json.RawMessage(nil), t.reward...)
 }
 
+// TypedReward decodes the reward, or returns nil when there is none or it does
+// not match the reward schema.
+func (t *Task) TypedReward() *Reward {
+	reward, _ := ParseReward(t.reward)
+	return reward
+}
+
+// RewardsMatchSchema reports whether the task and tier rewards all decode as
+// typed rewards. Rewards saved before the schema existed may not.
+func (t *Task) RewardsMatchSchema() bool {
+	if !isValidReward(t.reward) {
+		return false
+	}
+	for _, tier := range t.tiers {
+		if !isValidReward(tier.Reward) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tiers returns the claimable thresholds of the task. Tasks without explicit
+// tiers report a single tier built from target and reward.
+func (t *Task) Tiers() []TaskTier {
+	if len(t.tiers) == 0 {
+		return []TaskTier{{Target: t.target, Reward: t.Reward()}}
+	}
+	tiers := make([]TaskTier, 0, len(t.tiers))
+	for _, tier := range t.tiers {
+		tiers = append(tiers, TaskTier{Target: tier.Target, Reward: append(json.RawMessage(nil), tier.Reward...)})
+	}
+	return tiers
+}
+
+func (t *Task) HasTiers() bool {
+	return len(t.tiers) > 0
+}
+
+// Tier returns the tier with the given 1-based number.
+func (t *Task) Tier(number int) (TaskTier, error) {
+	tiers := t.Tiers()
+	if number < 1 || number > len(tiers) {
+		return TaskTier{}, exceptions.ErrTaskTierInvalid
+	}
+	return tiers[number-1], nil
+}
+
+// RepeatLimit is how many times per period the task can be completed and claimed.
+func (t *Task) RepeatLimit() int {
+	if t.repeatLimit <= 0 {
+		return 1
+	}
+	return t.repeatLimit
+}
+
+func (t *Task) IsRepeatable() bool {
+	return t.RepeatLimit() > 1
+}
+
+// Rule returns the event matching rule of the task, or nil when the task is
+// only advanced by events that reference it by ID.
+func (t *Task) Rule() *TaskRule {
+	return t.rule.clone()
+}
+
+// Aggregation returns how event values are combined into progress, summing
+// event amounts by default.
+func (t *Task) Aggregation() Aggregation {
+	aggregation := t.aggregation.clone()
+	if aggregation.Mode == "" {
+		aggregation.Mode = AggregationSum
+	}
+	return aggregation
+}
+
+// SeasonXP is the season XP granted each time a reward of the task is claimed.
+func (t *Task) SeasonXP() int {
+	return t.seasonXP
+}
+
+// Scope tells whose progress the task tracks; it defaults to the user's own.
+func (t *Task) Scope() TaskScope {
+	if t.scope == "" {
+		return TaskScopeUser
+	}
+	return t.scope
+}
+
+// IsRoomScoped reports whether everyone in a room shares the task progress.
+func (t *Task) IsRoomScoped() bool {
+	return t.Scope() == TaskScopeRoom
+}
+
+// Leaderboard returns the leaderboard configuration, or nil for unranked tasks.
+func (t *Task) Leaderboard() *LeaderboardConfig {
+	return t.leaderboard.clone()
+}
+
+func (t *Task) HasLeaderboard() bool {
+	return t.leaderboard != nil
+}
+
+// IsGroupScoped reports whether the members of a group share the task progress.
+func (t *Task) IsGroupScoped() bool {
+	return t.Scope() == TaskScopeGroup
+}
+
+// IsShared reports whether the task progress is shared by a room or group.
+func (t *Task) IsShared() bool {
+	return t.IsRoomScoped() || t.IsGroupScoped()
+}
+
 func (t *Task) IsActive() bool {
 	return t.isActive
 }
 
+// ResetPeriod returns how often progress starts over. Daily tasks reset every day
+// unless configured otherwise.
+func (t *Task) ResetPeriod() ResetPeriod {
+	if t.resetPeriod == ResetPeriodNone && t.taskType == TaskTypeDaily {
+		return ResetPeriodDaily
+	}
+	return t.resetPeriod
+}
+
+// Prerequisites lists tasks whose reward must be claimed before this task counts progress.
+func (t *Task) Prerequisites() []string {
+	if len(t.prereqs) == 0 {
+		return nil
+	}
+	return append([]string(nil), t.prereqs...)
+}
+
+// Children are the tasks of a quest; finishing Target of them completes it.
+func (t *Task) Children() []string {
+	if len(t.children) == 0 {
+		return nil
+	}
+	return append([]string(nil), t.children...)
+}
+
+func (t *Task) IsQuest() bool {
+	return len(t.children) > 0
+}
+
+// QuestIDs are the quests the task is a child of.
+func (t *Task) QuestIDs() []string {
+	if len(t.questIDs) == 0 {
+		return nil
+	}
+	return append([]string(nil), t.questIDs...)
+}
+
+// Streak returns the streak configuration, or nil for tasks that are not streaks.
+func (t *Task) Streak() *StreakConfig {
+	return t.streak.clone()
+}
+
+func (t *Task) IsStreak() bool {
+	return t.streak != nil
+}
+
+// StreakIDs are the streak tasks tracking this task.
+func (t *Task) StreakIDs() []string {
+	if len(t.streakIDs) == 0 {
+		return nil
+	}
+	return append([]string(nil), t.streakIDs...)
+}
+
+func (t *Task) StartsAt() time.Time {
+	return t.startsAt
+}
+
+func (t *Task) EndsAt() time.Time {
+	return t.endsAt
+}
+
 func (t *Task) CreatedAt() time.Time {
 	return t.createdAt
 }
+
+// SetSchedule limits the task to [startsAt, endsAt). A zero bound leaves that side open.
+func (t *Task) SetSchedule(startsAt, endsAt time.Time) {
+	t.startsAt = startsAt
+	t.endsAt = endsAt
+}
+
+func (t *Task) SetResetPeriod(period ResetPeriod) {
+	t.resetPeriod = period
+}
+
+func (t *Task) SetTiers(tiers []TaskTier) {
+	if len(tiers) == 0 {
+		t.tiers = nil
+		return
+	}
+	t.tiers = make([]TaskTier, 0, len(tiers))
+	for _, tier := range tiers {
+		t.tiers = append(t.tiers, TaskTier{Target: tier.Target, Reward: append(json.RawMessage(nil), tier.Reward...)})
+	}
+}
+
+func (t *Task) SetRepeatLimit(limit int) {
+	t.repeatLimit = limit
+}
+
+func (t *Task) SetRule(rule *TaskRule) {
+	t.rule = rule.clone()
+}
+
+func (t *Task) SetAggregation(aggregation Aggregation) {
+	t.aggregation = aggregation.clone()
+}
+
+func (t *Task) SetSeasonXP(xp int) {
+	t.seasonXP = xp
+}
+
+func (t *Task) SetScope(scope TaskScope) {
+	t.scope = scope
+}
+
+func (t *Task) SetLeaderboard(leaderboard *LeaderboardConfig) {
+	t.leaderboard = leaderboard.clone()
+}
+
+func (t *Task) SetPrerequisites(taskIDs []string) {
+	if len(taskIDs) == 0 {
+		t.prereqs = nil
+		return
+	}
+	t.prereqs = append([]string(nil), taskIDs...)
+}
+
+func (t *Task) SetChildren(taskIDs []string) {
+	if len(taskIDs) == 0 {
+		t.children = nil
+		return
+	}
+	t.children = append([]string(nil), taskIDs...)
+}
+
+func (t *Task) SetQuestIDs(questIDs []string) {
+	if len(questIDs) == 0 {
+		t.questIDs = nil
+		return
+	}
+	t.questIDs = append([]string(nil), questIDs...)
+}
+
+func (t *Task) SetStreak(streak *StreakConfig) {
+	t.streak = streak.clone()
+}
+
+func (t *Task) SetStreakIDs(streakIDs []string) {
+	if len(streakIDs) == 0 {
+		t.streakIDs = nil
+		return
+	}
+	t.streakIDs = append([]string(nil), streakIDs...)
+}
+
+func (t *Task) IsAvailableAt(at time.Time) bool {
+	if !t.startsAt.IsZero() && at.Before(t.startsAt) {
+		return false
+	}
+	if !t.endsAt.IsZero() && !at.Before(t.endsAt) {
+		return false
+	}
+	return true
+}
+
+func (t *Task) SetID(id string) {
+	t.id = id
+}
+
+func (t *Task) SetCreatedAt(at time.Time) {
+	t.createdAt = at
+}
+
+func (t *Task) Validate() error {
+	if strings.TrimSpace(t.title) == "" {
+		return exceptions.ErrTaskTitleRequired
+	}
+	if !t.taskType.IsValid() {
+		return exceptions.ErrTaskTypeInvalid
+	}
+	if t.target <= 0 {
+		return exceptions.ErrTaskTargetInvalid
+	}
+	if !t.resetPeriod.IsValid() {
+		return exceptions.ErrTaskResetPeriodInvalid
+	}
+	if !isValidReward(t.reward) {
+		return exceptions.ErrTaskRewardInvalid
+	}
+	if err := validateTiers(t.tiers, t.target); err != nil {
+		return err
+	}
+	if err := t.rule.Validate(); err != nil {
+		return err
+	}
+	if err := t.Aggregation().Validate(); err != nil {
+		return err
+	}
+	if t.repeatLimit < 0 || (t.repeatLimit > 1 && len(t.tiers) > 0) {
+		return exceptions.ErrTaskRepeatLimitInvalid
+	}
+	if t.repeatLimit > 1 && t.Aggregation().Mode != AggregationSum {
+		return exceptions.ErrTaskAggregationInvalid
+	}
+	if t.seasonXP < 0 {
+		return exceptions.ErrTaskSeasonXPInvalid
+	}
+	if err := t.validateSequence(); err != nil {
+		return err
+	}
+	if err := t.validateQuest(); err != nil {
+		return err
+	}
+	if err := t.validateStreak(); err != nil {
+		return err
+	}
+	if err := t.validateShared(); err != nil {
+		return err
+	}
+	if err := t.validateLeaderboard(); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(t.prereqs))
+	for _, prerequisiteID := range t.prereqs {
+		if prerequisiteID == "" || prerequisiteID == t.id {
+			return exceptions.ErrTaskPrerequisiteInvalid
+		}
+		if _, ok := seen[prerequisiteID]; ok {
+			return exceptions.ErrTaskPrerequisiteInvalid
+		}
+		seen[prerequisiteID] = struct{}{}
+	}
+	if !t.startsAt.IsZero() && !t.endsAt.IsZero() && !t.endsAt.After(t.startsAt) {
+		return exceptions.ErrTaskScheduleInvalid
+	}
+	return nil
+}
+
+// validateSequence checks that a sequence task completes exactly when its last
+// step is reached and that its rule lets every step's events through.
+func (t *Task) validateSequence() error {
+	aggregation := t.Aggregation()
+	if aggregation.Mode != AggregationSequence {
+		return nil
+	}
+	if t.target != len(aggregation.Steps) || len(t.tiers) > 0 {
+		return exceptions.ErrTaskAggregationInvalid
+	}
+	if t.rule == nil {
+		return nil
+	}
+	for _, step := range aggregation.Steps {
+		for _, eventType := range step.EventTypes {
+			if !slices.Contains(t.rule.EventTypes, eventType) {
+				return exceptions.ErrTaskAggregationInvalid
+			}
+		}
+	}
+	return nil
+}
+
+// validateQuest checks that a quest can be completed by its children and is
+// not advanced by events of its own.
+func (t *Task) validateQuest() error {
+	if !t.IsQuest() {
+		return nil
+	}
+	if t.target > len(t.children) || t.rule != nil || t.repeatLimit > 1 {
+		return exceptions.ErrTaskQuestInvalid
+	}
+	if aggregation := t.Aggregation(); aggregation.Mode != AggregationSum || aggregation.Attribute != "" {
+		return exceptions.ErrTaskQuestInvalid
+	}
+	seen := make(map[string]struct{}, len(t.children))
+	for _, childID := range t.children {
+		if childID == "" || childID == t.id {
+			return exceptions.ErrTaskQuestInvalid
+		}
+		if _, ok := seen[childID]; ok {
+			return exceptions.ErrTaskQuestInvalid
+		}
+		seen[childID] = struct{}{}
+	}
+	return nil
+}
+
+// validateShared checks that a room or group task adds every contribution to
+// one shared counter that is claimed once per contributor.
+func (t *Task) validateShared() error {
+	if !t.Scope().IsValid() {
+		return exceptions.ErrTaskScopeInvalid
+	}
+	if !t.IsShared() {
+		return nil
+	}
+	if t.IsQuest() || t.IsStreak() || len(t.tiers) > 0 || t.repeatLimit > 1 {
+		return exceptions.ErrTaskScopeInvalid
+	}
+	if t.Aggregation().Mode != AggregationSum {
+		return exceptions.ErrTaskScopeInvalid
+	}
+	return nil
+}
+
+// validateLeaderboard checks that a ranked task scores the user's own events
+// by adding them up or keeping the best one.
+func (t *Task) validateLeaderboard() error {
+	if t.leaderboard == nil {
+		return nil
+	}
+	if err := t.leaderboard.Validate(); err != nil {
+		return err
+	}
+	if t.IsShared() || t.IsQuest() || t.IsStreak() {
+		return exceptions.ErrTaskLeaderboardInvalid
+	}
+	if mode := t.Aggregation().Mode; mode != AggregationSum && mode != AggregationMax {
+		return exceptions.ErrTaskLeaderboardInvalid
+	}
+	return nil
+}
+
+// validateStreak checks that a streak task is advanced only by its tracked
+// tasks and keeps its progress across periods. Milestones are its tiers.
+func (t *Task) validateStreak() error {
+	if t.streak == nil {
+		return nil
+	}
+	if err := t.streak.Validate(t.id); err != nil {
+		return err
+	}
+	if t.IsQuest() || t.rule != nil || t.repeatLimit > 1 || t.resetPeriod != ResetPeriodNone || t.taskType == TaskTypeDaily {
+		return exceptions.ErrTaskStreakInvalid
+	}
+	if aggregation := t.Aggregation(); aggregation.Mode != AggregationSum || aggregation.Attribute != "" {
+		return exceptions.ErrTaskStreakInvalid
+	}
+	return nil
+}
+
+func (t TaskType) IsValid() bool {
+	switch t {
+	case TaskTypeSocial, TaskTypeDaily, TaskTypeGame:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"encoding/json"
+
+	"task-manager/internal/core/domain/exceptions"
+)
+
+// TaskTier is a claimable threshold of a task. Tiers are numbered from 1 in
+// ascending target order; a task without explicit tiers has a single implicit tier.
+type TaskTier struct {
+	Target int             `json:"target"`
+	Reward json.RawMessage `json:"reward,omitempty"`
+}
+
+// TypedReward decodes the tier reward like Task.TypedReward.
+func (t TaskTier) TypedReward() *Reward {
+	reward, _ := ParseReward(t.Reward)
+	return reward
+}
+
+type TierState struct {
+	Tier      int
+	Target    int
+	Completed bool
+	Claimed   bool
+}
+
+func validateTiers(tiers []TaskTier, target int) error {
+	if len(tiers) == 0 {
+		return nil
+	}
+	previous := 0
+	for _, tier := range tiers {
+		if tier.Target <= previous {
+			return exceptions.ErrTaskTiersInvalid
+		}
+		if !isValidReward(tier.Reward) {
+			return exceptions.ErrTaskRewardInvalid
+		}
+		previous = tier.Target
+	}
+	if previous != target {
+		return exceptions.ErrTaskTiersInvalid
+	}
+	return nil
+}
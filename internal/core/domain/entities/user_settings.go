@@ -0,0 +1,65 @@
+package entities
+
+import "time"
+
+// UserSettings holds the timezone used for the user's period boundaries. The
+// first timezone applies at once. Later changes stay pending until both the
+// old and the new timezone have started a new day, so a change never moves
+// the user back into a day they have already been in, and a user who flips
+// timezones does not get another period of daily or weekly tasks each time.
+type UserSettings struct {
+	UserID          string
+	Timezone        string
+	PendingTimezone string
+	PendingFrom     time.Time
+	UpdatedAt       time.Time
+}
+
+func NewUserSettings(userID, timezone string, at time.Time) *UserSettings {
+	return &UserSettings{
+		UserID:    userID,
+		Timezone:  timezone,
+		UpdatedAt: at,
+	}
+}
+
+// TimezoneAt returns the timezone in effect at the given instant.
+func (s *UserSettings) TimezoneAt(at time.Time) string {
+	if s.PendingTimezone != "" && !at.Before(s.PendingFrom) {
+		return s.PendingTimezone
+	}
+	return s.Timezone
+}
+
+// ChangeTimezone schedules the timezone to apply once both the timezone in
+// effect and the new one have passed their next midnight. Changing back to
+// the timezone in effect cancels the pending change.
+func (s *UserSettings) ChangeTimezone(timezone string, at time.Time) error {
+	next, err := LoadTimezone(timezone)
+	if err != nil {
+		return err
+	}
+	if timezone == s.PendingTimezone && at.Before(s.PendingFrom) {
+		return nil
+	}
+	s.Timezone = s.TimezoneAt(at)
+	s.PendingTimezone = ""
+	s.PendingFrom = time.Time{}
+	s.UpdatedAt = at
+	if timezone == s.Timezone {
+		return nil
+	}
+
+	current, err := LoadTimezone(s.Timezone)
+	if err != nil {
+		// The stored timezone is unusable; nothing can be re-entered.
+		s.Timezone = timezone
+		return nil
+	}
+	s.PendingTimezone = timezone
+	s.PendingFrom = PeriodEnd(ResetPeriodDaily, at, current)
+	if end := PeriodEnd(ResetPeriodDaily, at, next); end.After(s.PendingFrom) {
+		s.PendingFrom = end
+	}
+	return nil
+}
@@ -3,17 +3,73 @@ package exceptions
 import "errors"
 
 var (
-	ErrTaskNotCompleted     = errors.New("task is not completed yet")
-	ErrRewardAlreadyClaimed = errors.New("reward already claimed")
-	ErrTaskNotFound         = errors.New("task not found")
-	ErrProgressNotFound     = errors.New("progress not found")
-	ErrTaskInactive         = errors.New("task is inactive")
-	ErrEventNil             = errors.New("event is nil")
-	ErrEventIDRequired      = errors.New("event_id is required")
-	ErrEventUserIDRequired  = errors.New("user_id is required")
-	ErrEventTypeRequired    = errors.New("event type is required")
-	ErrUnsupportedEventType = errors.New("unsupported event type")
-	ErrEventPayloadInvalid  = errors.New("event payload is invalid")
-	ErrEventTaskIDRequired  = errors.New("event task_id is required")
-	ErrEventAmountInvalid   = errors.New("event amount is invalid")
+	ErrTaskNotCompleted         = errors.New("task is not completed yet")
+	ErrRewardAlreadyClaimed     = errors.New("reward already claimed")
+	ErrTaskNotFound             = errors.New("task not found")
+	ErrProgressNotFound         = errors.New("progress not found")
+	ErrTaskInactive             = errors.New("task is inactive")
+	ErrTaskTitleRequired        = errors.New("task title is required")
+	ErrTaskTypeInvalid          = errors.New("task type is invalid")
+	ErrTaskTargetInvalid        = errors.New("task target is invalid")
+	ErrTaskRewardInvalid        = errors.New("task reward is invalid")
+	ErrTaskScheduleInvalid      = errors.New("task schedule is invalid")
+	ErrTaskResetPeriodInvalid   = errors.New("task reset period is invalid")
+	ErrTaskNotAvailable         = errors.New("task is outside its availability window")
+	ErrTaskRuleInvalid          = errors.New("task rule is invalid")
+	ErrTaskConditionInvalid     = errors.New("task condition is invalid")
+	ErrEventConditionNotMet     = errors.New("event does not satisfy task condition")
+	ErrTaskAggregationInvalid   = errors.New("task aggregation is invalid")
+	ErrTaskRepeatLimitInvalid   = errors.New("task repeat limit is invalid")
+	ErrTaskTiersInvalid         = errors.New("task tiers are invalid")
+	ErrTaskTierInvalid          = errors.New("task tier is invalid")
+	ErrTaskPrerequisiteInvalid  = errors.New("task prerequisite is invalid")
+	ErrTaskPrerequisiteNotFound = errors.New("task prerequisite not found")
+	ErrTaskPrerequisiteCycle    = errors.New("task prerequisites form a cycle")
+	ErrTaskLocked               = errors.New("task is locked by prerequisites")
+	ErrTaskQuestInvalid         = errors.New("task quest is invalid")
+	ErrTaskQuestChildNotFound   = errors.New("task quest child not found")
+	ErrTaskQuestCycle           = errors.New("task quests form a cycle")
+	ErrQuestProgressDerived     = errors.New("quest progress comes from its child tasks")
+	ErrTaskStreakInvalid        = errors.New("task streak is invalid")
+	ErrTaskStreakSourceInvalid  = errors.New("task streak source must be an existing daily task")
+	ErrStreakProgressDerived    = errors.New("streak progress comes from its tracked tasks")
+	ErrTaskSeasonXPInvalid      = errors.New("task season xp is invalid")
+	ErrSeasonNotFound           = errors.New("season not found")
+	ErrSeasonInvalid            = errors.New("season is invalid")
+	ErrSeasonOverlap            = errors.New("season overlaps another season")
+	ErrSeasonLevelInvalid       = errors.New("season level is invalid")
+	ErrSeasonTrackInvalid       = errors.New("season track is invalid")
+	ErrSeasonLevelNotReached    = errors.New("season level is not reached yet")
+	ErrSeasonPremiumRequired    = errors.New("season premium pass is required")
+	ErrAchievementInvalid       = errors.New("achievement is invalid")
+	ErrTaskScopeInvalid         = errors.New("task scope is invalid")
+	ErrTaskSharedSourceInvalid  = errors.New("room and group tasks cannot feed quests or streaks")
+	ErrEventRoomRequired        = errors.New("event room_id is required for room tasks")
+	ErrTaskLeaderboardInvalid   = errors.New("task leaderboard is invalid")
+	ErrLeaderboardDisabled      = errors.New("task has no leaderboard")
+	ErrGroupInvalid             = errors.New("group is invalid")
+	ErrGroupNotFound            = errors.New("group not found")
+	ErrGroupMemberNotFound      = errors.New("user is not a member of the group")
+	ErrGroupMembershipExists    = errors.New("user already belongs to a group")
+	ErrGroupMembershipRequired  = errors.New("user must belong to a group for group tasks")
+	ErrLedgerSpendInvalid       = errors.New("spend is invalid")
+	ErrLedgerReferenceExists    = errors.New("ledger transaction with this reference already exists")
+	ErrLedgerRewardUnsupported  = errors.New("reward holds xp or bundles, which the ledger cannot credit")
+	ErrLedgerPageTokenInvalid   = errors.New("ledger page token is invalid")
+	ErrInsufficientBalance      = errors.New("insufficient balance")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used for another request")
+	ErrIdempotencyKeyExists     = errors.New("claim with this idempotency key already exists")
+	ErrUserSettingsNotFound     = errors.New("user settings not found")
+	ErrTimezoneInvalid          = errors.New("timezone is invalid")
+	ErrSessionNotFound          = errors.New("session not found")
+	ErrEventNil                 = errors.New("event is nil")
+	ErrEventIDRequired          = errors.New("event_id is required")
+	ErrEventUserIDRequired      = errors.New("user_id is required")
+	ErrEventTypeRequired        = errors.New("event type is required")
+	ErrEventPayloadInvalid      = errors.New("event payload is invalid")
+	ErrEventAmountInvalid       = errors.New("event amount is invalid")
+	ErrEventAttributesInvalid   = errors.New("event attributes are invalid")
+	ErrEventAttributeMissing    = errors.New("event attribute is missing or invalid")
+	ErrEventValueOutOfRange     = errors.New("event value is out of range")
+	ErrEventTooLate             = errors.New("event is too old to count towards timed tasks")
 )
@@ -0,0 +1,260 @@
+// Package expression implements the condition language used by task rules,
+// e.g. `score >= 1000 && mode == "ranked"`.
+//
+// The language is deliberately small: attribute names, string/number/boolean
+// literals, comparisons (== != < <= > >=), logical operators (&& || !) and
+// parentheses. There are no function calls, loops or assignments, so evaluation
+// is bounded by the size of the compiled expression.
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	maxSourceLength = 1024
+	maxDepth        = 32
+	maxCached       = 1024
+)
+
+var errNoMatch = errors.New("attribute missing or of unexpected type")
+
+// cache holds up to maxCached compiled programs by source text. Sources come
+// from task rules, so a full cache drops an arbitrary entry rather than
+// tracking use.
+var cache = struct {
+	sync.Mutex
+	programs map[string]*Program
+}{programs: make(map[string]*Program)}
+
+// Program is a compiled, type-checked condition.
+type Program struct {
+	source string
+	root   node
+}
+
+// Compile parses and type-checks src. Results are cached by source text.
+func Compile(src string) (*Program, error) {
+	if cached, ok := cached(src); ok {
+		return cached, nil
+	}
+	if len(src) > maxSourceLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", maxSourceLength)
+	}
+
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+	if k := root.kind(); k != kindBool && k != kindAny {
+		return nil, errors.New("expression must evaluate to a boolean")
+	}
+
+	program := &Program{source: src, root: root}
+	store(program)
+	return program, nil
+}
+
+func cached(src string) (*Program, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+	program, ok := cache.programs[src]
+	return program, ok
+}
+
+func store(program *Program) {
+	cache.Lock()
+	defer cache.Unlock()
+	if len(cache.programs) >= maxCached {
+		for src := range cache.programs {
+			delete(cache.programs, src)
+			break
+		}
+	}
+	cache.programs[program.source] = program
+}
+
+func (p *Program) String() string {
+	return p.source
+}
+
+// Eval reports whether the condition holds for vars. Missing attributes and
+// attributes of an unexpected type make the condition false.
+func (p *Program) Eval(vars map[string]any) bool {
+	value, err := p.root.eval(vars)
+	if err != nil {
+		return false
+	}
+	result, ok := value.(bool)
+	return ok && result
+}
+
+type valueKind int
+
+const (
+	kindAny valueKind = iota
+	kindBool
+	kindNumber
+	kindString
+)
+
+type node interface {
+	kind() valueKind
+	eval(vars map[string]any) (any, error)
+}
+
+type literalNode struct {
+	value any
+	k     valueKind
+}
+
+func (n literalNode) kind() valueKind { return n.k }
+
+func (n literalNode) eval(map[string]any) (any, error) { return n.value, nil }
+
+type identNode struct {
+	name string
+}
+
+func (n identNode) kind() valueKind { return kindAny }
+
+func (n identNode) eval(vars map[string]any) (any, error) {
+	value, ok := vars[n.name]
+	if !ok {
+		return nil, errNoMatch
+	}
+	switch typed := value.(type) {
+	case int:
+		return float64(typed), nil
+	case int32:
+		return float64(typed), nil
+	case int64:
+		return float64(typed), nil
+	default:
+		return value, nil
+	}
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) kind() valueKind { return kindBool }
+
+func (n notNode) eval(vars map[string]any) (any, error) {
+	value, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return nil, errNoMatch
+	}
+	return !b, nil
+}
+
+type logicalNode struct {
+	op          tokenKind
+	left, right node
+}
+
+func (n logicalNode) kind() valueKind { return kindBool }
+
+func (n logicalNode) eval(vars map[string]any) (any, error) {
+	left := evalBool(n.left, vars)
+	if n.op == tokenAnd && !left {
+		return false, nil
+	}
+	if n.op == tokenOr && left {
+		return true, nil
+	}
+	return evalBool(n.right, vars), nil
+}
+
+// evalBool treats a failed or non-boolean operand as false so that
+// `a == 1 || b == 2` still matches when only b is present.
+func evalBool(n node, vars map[string]any) bool {
+	value, err := n.eval(vars)
+	if err != nil {
+		return false
+	}
+	b, ok := value.(bool)
+	return ok && b
+}
+
+type compareNode struct {
+	op          tokenKind
+	left, right node
+}
+
+func (n compareNode) kind() valueKind { return kindBool }
+
+func (n compareNode) eval(vars map[string]any) (any, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return nil, errNoMatch
+		}
+		return compareOrdered(n.op, l, r), nil
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return nil, errNoMatch
+		}
+		return compareOrdered(n.op, l, r), nil
+	case bool:
+		r, ok := right.(bool)
+		if !ok {
+			return nil, errNoMatch
+		}
+		switch n.op {
+		case tokenEq:
+			return l == r, nil
+		case tokenNeq:
+			return l != r, nil
+		default:
+			return nil, errNoMatch
+		}
+	default:
+		return nil, errNoMatch
+	}
+}
+
+func compareOrdered[T float64 | string](op tokenKind, l, r T) bool {
+	switch op {
+	case tokenEq:
+		return l == r
+	case tokenNeq:
+		return l != r
+	case tokenLt:
+		return l < r
+	case tokenLte:
+		return l <= r
+	case tokenGt:
+		return l > r
+	case tokenGte:
+		return l >= r
+	default:
+		return false
+	}
+}
@@ -0,0 +1,145 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]any{
+		"score":  1500,
+		"kills":  int64(3),
+		"ratio":  0.75,
+		"mode":   "ranked",
+		"ranked": true,
+		"map.id": "dust",
+	}
+
+	tests := []struct {
+		name string
+		src  string
+		want bool
+	}{
+		{name: "number comparison", src: `score >= 1000`, want: true},
+		{name: "int64 attribute", src: `kills == 3`, want: true},
+		{name: "float attribute", src: `ratio < 1`, want: true},
+		{name: "negative literal", src: `score > -1`, want: true},
+		{name: "string equality", src: `mode == "ranked"`, want: true},
+		{name: "single quoted string", src: `mode != 'casual'`, want: true},
+		{name: "string ordering", src: `mode < "solo"`, want: true},
+		{name: "escaped quote", src: `mode != "rank\"ed"`, want: true},
+		{name: "boolean attribute", src: `ranked`, want: true},
+		{name: "boolean comparison", src: `ranked == false`, want: false},
+		{name: "dotted attribute", src: `map.id == "dust"`, want: true},
+		{name: "literal on the left", src: `1000 <= score`, want: true},
+		{name: "and binds tighter than or", src: `score < 0 && mode == "casual" || ranked`, want: true},
+		{name: "or with and on the right", src: `ranked || score < 0 && false`, want: true},
+		{name: "parentheses override precedence", src: `(ranked || score < 0) && false`, want: false},
+		{name: "not binds tighter than and", src: `!ranked && score > 0`, want: false},
+		{name: "not of a group", src: `!(score < 0 || mode == "casual")`, want: true},
+		{name: "double negation", src: `!!ranked`, want: true},
+		{name: "missing attribute", src: `level > 1`, want: false},
+		{name: "missing attribute in or", src: `level > 1 || score > 1`, want: true},
+		{name: "missing attribute under not", src: `!(level > 1)`, want: false},
+		{name: "attribute of unexpected type", src: `mode > 1`, want: false},
+		{name: "non-boolean attribute as condition", src: `score`, want: false},
+		{name: "attributes of different types", src: `score == mode`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := Compile(tt.src)
+			if err != nil {
+				t.Fatalf("Compile(%q) error = %v", tt.src, err)
+			}
+			if got := program.Eval(vars); got != tt.want {
+				t.Errorf("Eval(%q) = %v, want %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{name: "empty", src: ``, wantErr: "unexpected end of expression"},
+		{name: "missing operand", src: `score >=`, wantErr: "unexpected end of expression"},
+		{name: "dangling operator", src: `score >= 1 &&`, wantErr: "unexpected end of expression"},
+		{name: "unclosed parenthesis", src: `(score > 1`, wantErr: "expected )"},
+		{name: "unopened parenthesis", src: `score > 1)`, wantErr: "unexpected"},
+		{name: "trailing operand", src: `score > 1 mode`, wantErr: "unexpected"},
+		{name: "unterminated string", src: `mode == "ranked`, wantErr: "unterminated string"},
+		{name: "unknown escape", src: `mode == "\x"`, wantErr: "invalid string"},
+		{name: "invalid number", src: `score > 1.2.3`, wantErr: "invalid number"},
+		{name: "unexpected character", src: `score > 1 # comment`, wantErr: "unexpected character"},
+		{name: "single ampersand", src: `ranked & ranked`, wantErr: "unexpected character"},
+		{name: "chained comparison", src: `1 < score < 10`, wantErr: "chained comparison"},
+		{name: "number compared with string", src: `1 == "1"`, wantErr: "different types"},
+		{name: "ordered booleans", src: `true < false`, wantErr: "cannot order booleans"},
+		{name: "not of a number", src: `!1`, wantErr: "expects a boolean"},
+		{name: "logical operand is a string", src: `ranked && "yes"`, wantErr: "boolean operands"},
+		{name: "result is a number", src: `1`, wantErr: "must evaluate to a boolean"},
+		{name: "too long", src: strings.Repeat(" ", maxSourceLength) + "ranked", wantErr: "longer than"},
+		{name: "nested parentheses too deep", src: strings.Repeat("(", maxDepth+1) + "ranked" + strings.Repeat(")", maxDepth+1), wantErr: "nested deeper"},
+		{name: "negations too deep", src: strings.Repeat("!", maxDepth+1) + "ranked", wantErr: "nested deeper"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.src)
+			if err == nil {
+				t.Fatalf("Compile(%q) error = nil, want %q", tt.src, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Compile(%q) error = %q, want it to contain %q", tt.src, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompileAtLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "longest source", src: strings.Repeat(" ", maxSourceLength-len("ranked")) + "ranked"},
+		{name: "deepest parentheses", src: strings.Repeat("(", maxDepth) + "ranked" + strings.Repeat(")", maxDepth)},
+		{name: "deepest negations", src: strings.Repeat("!", maxDepth) + "ranked"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compile(tt.src); err != nil {
+				t.Errorf("Compile() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCompileCacheIsBounded(t *testing.T) {
+	for i := range maxCached + 10 {
+		if _, err := Compile(fmt.Sprintf("score == %d", i)); err != nil {
+			t.Fatalf("Compile() error = %v", err)
+		}
+	}
+
+	cache.Lock()
+	size := len(cache.programs)
+	cache.Unlock()
+	if size > maxCached {
+		t.Errorf("cache holds %d programs, want at most %d", size, maxCached)
+	}
+
+	first, err := Compile(`score == -1`)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	second, err := Compile(`score == -1`)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if first != second {
+		t.Error("Compile() did not reuse the cached program")
+	}
+}
@@ -0,0 +1,159 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenTrue
+	tokenFalse
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenEq
+	tokenNeq
+	tokenLt
+	tokenLte
+	tokenGt
+	tokenGte
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+func tokenize(src string) ([]token, error) {
+	tokens := make([]token, 0, 16)
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case strings.HasPrefix(src[i:], "&&"):
+			tokens = append(tokens, token{kind: tokenAnd, text: "&&", pos: i})
+			i += 2
+		case strings.HasPrefix(src[i:], "||"):
+			tokens = append(tokens, token{kind: tokenOr, text: "||", pos: i})
+			i += 2
+		case strings.HasPrefix(src[i:], "=="):
+			tokens = append(tokens, token{kind: tokenEq, text: "==", pos: i})
+			i += 2
+		case strings.HasPrefix(src[i:], "!="):
+			tokens = append(tokens, token{kind: tokenNeq, text: "!=", pos: i})
+			i += 2
+		case strings.HasPrefix(src[i:], "<="):
+			tokens = append(tokens, token{kind: tokenLte, text: "<=", pos: i})
+			i += 2
+		case strings.HasPrefix(src[i:], ">="):
+			tokens = append(tokens, token{kind: tokenGte, text: ">=", pos: i})
+			i += 2
+		case c == '<':
+			tokens = append(tokens, token{kind: tokenLt, text: "<", pos: i})
+			i++
+		case c == '>':
+			tokens = append(tokens, token{kind: tokenGt, text: ">", pos: i})
+			i++
+		case c == '!':
+			tokens = append(tokens, token{kind: tokenNot, text: "!", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(src) && src[end] != c {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			text, err := unquote(src[i+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %w", i, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = end + 1
+		case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(src) && (src[end] == '.' || (src[end] >= '0' && src[end] <= '9')) {
+				end++
+			}
+			num, err := strconv.ParseFloat(src[i:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at %d", src[i:end], i)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[i:end], num: num, pos: i})
+			i = end
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			end := i + 1
+			for end < len(src) && isIdentChar(src[end]) {
+				end++
+			}
+			word := src[i:end]
+			switch word {
+			case "true":
+				tokens = append(tokens, token{kind: tokenTrue, text: word, pos: i})
+			case "false":
+				tokens = append(tokens, token{kind: tokenFalse, text: word, pos: i})
+			default:
+				tokens = append(tokens, token{kind: tokenIdent, text: word, pos: i})
+			}
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(src)})
+	return tokens, nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '.' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func unquote(body string) (string, error) {
+	if !strings.Contains(body, `\`) {
+		return body, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\\' {
+			b.WriteByte(body[i])
+			continue
+		}
+		i++
+		if i >= len(body) {
+			return "", fmt.Errorf("dangling escape")
+		}
+		switch body[i] {
+		case '\\', '"', '\'':
+			b.WriteByte(body[i])
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		default:
+			return "", fmt.Errorf("unknown escape \\%c", body[i])
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,152 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+)
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseOr(depth int) (node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkLogicalOperands(left, right); err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: tokenOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (node, error) {
+	left, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkLogicalOperands(left, right); err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: tokenAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot(depth int) (node, error) {
+	if p.peek().kind != tokenNot {
+		return p.parseComparison(depth)
+	}
+	tok := p.next()
+	if depth+1 > maxDepth {
+		return nil, fmt.Errorf("expression is nested deeper than %d levels", maxDepth)
+	}
+	operand, err := p.parseNot(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	if k := operand.kind(); k != kindBool && k != kindAny {
+		return nil, fmt.Errorf("operator ! at %d expects a boolean", tok.pos)
+	}
+	return notNode{operand: operand}, nil
+}
+
+func (p *parser) parseComparison(depth int) (node, error) {
+	left, err := p.parseOperand(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	switch op.kind {
+	case tokenEq, tokenNeq, tokenLt, tokenLte, tokenGt, tokenGte:
+	default:
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseOperand(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	lk, rk := left.kind(), right.kind()
+	if lk != kindAny && rk != kindAny && lk != rk {
+		return nil, fmt.Errorf("operator %s at %d compares values of different types", op.text, op.pos)
+	}
+	if op.kind != tokenEq && op.kind != tokenNeq && (lk == kindBool || rk == kindBool) {
+		return nil, fmt.Errorf("operator %s at %d cannot order booleans", op.text, op.pos)
+	}
+	if next := p.peek().kind; next >= tokenEq && next <= tokenGte {
+		return nil, fmt.Errorf("chained comparison at %d, use && instead", p.peek().pos)
+	}
+	return compareNode{op: op.kind, left: left, right: right}, nil
+}
+
+func (p *parser) parseOperand(depth int) (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return literalNode{value: tok.num, k: kindNumber}, nil
+	case tokenString:
+		return literalNode{value: tok.text, k: kindString}, nil
+	case tokenTrue:
+		return literalNode{value: true, k: kindBool}, nil
+	case tokenFalse:
+		return literalNode{value: false, k: kindBool}, nil
+	case tokenIdent:
+		return identNode{name: tok.text}, nil
+	case tokenLParen:
+		if depth+1 > maxDepth {
+			return nil, fmt.Errorf("expression is nested deeper than %d levels", maxDepth)
+		}
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ) at %d", closing.pos)
+		}
+		return inner, nil
+	case tokenEOF:
+		return nil, errors.New("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+}
+
+func checkLogicalOperands(left, right node) error {
+	for _, operand := range []node{left, right} {
+		if k := operand.kind(); k != kindBool && k != kindAny {
+			return errors.New("logical operators expect boolean operands")
+		}
+	}
+	return nil
+}
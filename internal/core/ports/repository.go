@@ -8,18 +8,165 @@ import (
 
 type TaskRepository interface {
 	GetByID(ctx context.Context, id string) (*entities.Task, error)
-	ListActive(ctx context.Context) ([]*entities.Task, error)
+	ListActive(ctx context.Context, at time.Time) ([]*entities.Task, error)
+	ListActiveByEventType(ctx context.Context, eventType entities.TaskEventType, at time.Time) ([]*entities.Task, error)
+	ListAll(ctx context.Context) ([]*entities.Task, error)
+	Create(ctx context.Context, task *entities.Task) error
+	Update(ctx context.Context, task *entities.Task) error
+	Deactivate(ctx context.Context, id string) error
+	SetPrerequisites(ctx context.Context, taskID string, prerequisiteIDs []string) error
+	SetChildren(ctx context.Context, questID string, childIDs []string) error
 }
 
 type ProgressRepository interface {
-	Get(ctx context.Context, userID string, taskID string) (*entities.TaskProgress, error)
+	Get(ctx context.Context, userID string, taskID string, periodKey string) (*entities.TaskProgress, error)
 	Create(ctx context.Context, progress *entities.TaskProgress) error
 	Update(ctx context.Context, progress *entities.TaskProgress) error
-	AddProgress(ctx context.Context, userID string, taskID string, amount int, target int, updatedAt time.Time) error
-	Claim(ctx context.Context, userID string, taskID string) error
+	AddProgress(ctx context.Context, userID string, taskID string, periodKey string, amount int, target int, repeatLimit int, updatedAt time.Time) error
+	SetProgress(ctx context.Context, userID string, taskID string, periodKey string, value int, target int, mode entities.AggregationMode, updatedAt time.Time) error
+	AddDistinct(ctx context.Context, userID string, taskID string, periodKey string, value string, target int, updatedAt time.Time) error
+	AddMark(ctx context.Context, userID string, taskID string, periodKey string, mark entities.ProgressMark) error
+	// ListMarks returns marks that occurred in [from, to]; zero bounds are open.
+	ListMarks(ctx context.Context, userID string, taskID string, periodKey string, from time.Time, to time.Time) ([]entities.ProgressMark, error)
+	ClearMarks(ctx context.Context, userID string, taskID string, periodKey string) error
+	// DeleteMarksBefore drops the marks that occurred before the given time.
+	DeleteMarksBefore(ctx context.Context, userID string, taskID string, periodKey string, before time.Time) error
+	// DeleteMarks drops the given marks.
+	DeleteMarks(ctx context.Context, userID string, taskID string, periodKey string, marks []entities.ProgressMark) error
+	// DeletePastPeriodMarks drops the marks of every period but the given one.
+	DeletePastPeriodMarks(ctx context.Context, userID string, taskID string, periodKey string) error
+	GetStreak(ctx context.Context, userID string, taskID string) (*entities.Streak, error)
+	SaveStreak(ctx context.Context, streak *entities.Streak) error
+	Claim(ctx context.Context, userID string, taskID string, periodKey string, tier int, tierTarget int, tierCount int) error
+	ClaimRepetition(ctx context.Context, userID string, taskID string, periodKey string, repeatLimit int) error
+	ClaimedTaskIDs(ctx context.Context, userID string, taskIDs []string) ([]string, error)
+	// ListClaimable returns the user's progress rows with rewards left to
+	// claim, across all periods: reached but unclaimed tiers, completed but
+	// unclaimed repetitions and completed single-reward tasks.
+	ListClaimable(ctx context.Context, userID string) ([]*entities.TaskProgress, error)
+}
+
+type UserRepository interface {
+	// GetSettings returns ErrUserSettingsNotFound when the user has none.
+	GetSettings(ctx context.Context, userID string) (*entities.UserSettings, error)
+	// CreateSettings stores the settings unless the user already has some.
+	CreateSettings(ctx context.Context, settings *entities.UserSettings) error
+	SaveSettings(ctx context.Context, settings *entities.UserSettings) error
 }
 
 type EventRepository interface {
 	IsProcessed(ctx context.Context, eventID string) (bool, error)
 	MarkProcessed(ctx context.Context, event *entities.TaskEvent) error
 }
+
+type SessionRepository interface {
+	Start(ctx context.Context, session *entities.Session) error
+	// Stop removes and returns the open session, or ErrSessionNotFound.
+	Stop(ctx context.Context, userID string, sessionKey string) (*entities.Session, error)
+	// StopStartedBefore removes and returns up to limit sessions started
+	// before the given instant, oldest first.
+	StopStartedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.Session, error)
+}
+
+type SeasonRepository interface {
+	GetByID(ctx context.Context, id string) (*entities.Season, error)
+	GetActive(ctx context.Context, at time.Time) (*entities.Season, error)
+	List(ctx context.Context) ([]*entities.Season, error)
+	Overlaps(ctx context.Context, startsAt time.Time, endsAt time.Time) (bool, error)
+	Create(ctx context.Context, season *entities.Season) error
+	GetProgress(ctx context.Context, userID string, seasonID string) (*entities.SeasonProgress, error)
+	AddXP(ctx context.Context, userID string, seasonID string, xp int, updatedAt time.Time) error
+	SetPremium(ctx context.Context, userID string, seasonID string, premium bool, updatedAt time.Time) error
+	ClaimLevel(ctx context.Context, userID string, seasonID string, level int, track entities.SeasonTrack, requiredXP int) error
+}
+
+type RoomRepository interface {
+	// AddProgress adds the member's amount to the room counter, up to target,
+	// and reports whether this update completed the room.
+	AddProgress(ctx context.Context, taskID string, roomID string, periodKey string, userID string, amount int, target int, updatedAt time.Time) (bool, error)
+	Get(ctx context.Context, taskID string, roomID string, periodKey string) (*entities.RoomProgress, error)
+	// FindUserRoom returns the room the user contributed to, preferring one with
+	// a reward still to claim, or ErrProgressNotFound.
+	FindUserRoom(ctx context.Context, userID string, taskID string, periodKey string) (string, error)
+	Claim(ctx context.Context, userID string, taskID string, roomID string, periodKey string) error
+}
+
+type LeaderboardRepository interface {
+	// Record applies a progress update to the user's entry, creating it if
+	// needed: sum adds value to the score and max keeps the best one. The
+	// entry is marked completed once the score reaches target; ties rank by
+	// completion time, or by the last change before completion.
+	Record(ctx context.Context, taskID string, periodKey string, userID string, value int, mode entities.AggregationMode, target int, at time.Time) error
+	Top(ctx context.Context, taskID string, periodKey string, limit int) ([]entities.LeaderboardEntry, error)
+	// Around returns the user's entry between up to radius entries ranked right
+	// above and below it, or ErrProgressNotFound when the user has no score.
+	Around(ctx context.Context, taskID string, periodKey string, userID string, radius int) ([]entities.LeaderboardEntry, error)
+	Count(ctx context.Context, taskID string, periodKey string) (int, error)
+}
+
+type GroupRepository interface {
+	Create(ctx context.Context, group *entities.Group) error
+	GetByID(ctx context.Context, id string) (*entities.Group, error)
+	// Join adds the user to the group, or returns ErrGroupMembershipExists when
+	// the user already belongs to a group.
+	Join(ctx context.Context, groupID string, userID string, joinedAt time.Time) (*entities.GroupMember, error)
+	// Leave removes the user from the group, or returns ErrGroupMemberNotFound.
+	Leave(ctx context.Context, groupID string, userID string) error
+	ListMembers(ctx context.Context, groupID string) ([]entities.GroupMember, error)
+	// GetUserGroup returns the group the user belongs to, or ErrGroupMemberNotFound.
+	GetUserGroup(ctx context.Context, userID string) (string, error)
+}
+
+type AchievementRepository interface {
+	List(ctx context.Context) ([]*entities.Achievement, error)
+	Create(ctx context.Context, achievement *entities.Achievement) error
+	// ListUnlocked returns unlock times keyed by achievement ID.
+	ListUnlocked(ctx context.Context, userID string) (map[string]time.Time, error)
+	// Unlock records the achievement once; later calls keep the first time. It
+	// reports whether this call unlocked the achievement.
+	Unlock(ctx context.Context, userID string, achievementID string, unlockedAt time.Time) (bool, error)
+	GetCounters(ctx context.Context, userID string) (map[string]int64, error)
+	IncrementCounters(ctx context.Context, userID string, counters []string, updatedAt time.Time) error
+	// MarkTaskCompleted reports whether the task was completed for the first time.
+	MarkTaskCompleted(ctx context.Context, userID string, taskID string, completedAt time.Time) (bool, error)
+	// ListCompletedTasks returns first completion times keyed by task ID.
+	ListCompletedTasks(ctx context.Context, userID string) (map[string]time.Time, error)
+}
+
+type RewardOutboxRepository interface {
+	// Enqueue stores the grant and sets its ID. Only pending grants are leased
+	// for delivery.
+	Enqueue(ctx context.Context, grant *entities.RewardGrant) error
+	// Lease returns up to limit pending grants due at now and hides them from
+	// other dispatchers until leaseUntil, so a crashed dispatcher's grants are
+	// picked up again once the lease runs out.
+	Lease(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*entities.RewardGrant, error)
+	// SaveDelivery records the outcome of a delivery attempt.
+	SaveDelivery(ctx context.Context, grant *entities.RewardGrant) error
+	// CountPending returns how many grants are waiting for delivery.
+	CountPending(ctx context.Context) (int, error)
+}
+
+type LedgerRepository interface {
+	// Post records the transaction and applies its entries to user balances. It
+	// returns ErrLedgerReferenceExists when the reference was already posted and
+	// ErrInsufficientBalance when a balance would go negative.
+	Post(ctx context.Context, tx *entities.LedgerTransaction) error
+	ListBalances(ctx context.Context, userID string) ([]entities.Balance, error)
+	// GetBalance returns a zero balance for assets the user never held.
+	GetBalance(ctx context.Context, userID string, kind entities.AssetKind, asset string) (entities.Balance, error)
+	// ListTransactions returns up to limit transactions newest first, starting
+	// after the transaction afterID; an empty afterID starts from the latest.
+	// It returns ErrLedgerPageTokenInvalid when afterID is not one of the
+	// user's transactions.
+	ListTransactions(ctx context.Context, userID string, afterID string, limit int) ([]*entities.LedgerTransaction, error)
+}
+
+type RewardClaimRepository interface {
+	// Get returns the claim the user made with the idempotency key, or nil.
+	Get(ctx context.Context, userID string, idempotencyKey string) (*entities.RewardClaim, error)
+	// Save returns ErrIdempotencyKeyExists when a claim with the key was
+	// already saved, including by a concurrent transaction that committed
+	// while this one waited for it.
+	Save(ctx context.Context, claim *entities.RewardClaim) error
+}
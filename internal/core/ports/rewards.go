@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"task-manager/internal/core/domain/entities"
+)
+
+// RewardSink hands claimed rewards to whatever grants them, such as the game
+// backend. A grant may be delivered more than once, so sinks must drop
+// redeliveries by grant ID.
+type RewardSink interface {
+	Deliver(ctx context.Context, grant *entities.RewardGrant) error
+}
@@ -10,5 +10,31 @@ type TaskUseCases interface {
 	GetTask(ctx context.Context, taskID string) (*entities.Task, error)
 	ProcessEvent(ctx context.Context, event *entities.TaskEvent) error
 	ProcessEvents(ctx context.Context, events []*entities.TaskEvent) (int32, int32, error)
-	ClaimReward(ctx context.Context, userID string, taskID string) error
+	ClaimReward(ctx context.Context, userID string, taskID string, tier int, idempotencyKey string) (*entities.RewardClaim, *entities.TaskProgress, error)
+	ClaimAllRewards(ctx context.Context, userID string) ([]entities.TaskClaims, *entities.Reward, error)
+	SetUserTimezone(ctx context.Context, userID string, timezone string) (*entities.UserSettings, error)
+	GetSeason(ctx context.Context, userID string) (*entities.Season, *entities.SeasonProgress, error)
+	ClaimSeasonReward(ctx context.Context, userID string, seasonID string, level int, track entities.SeasonTrack) (*entities.Season, *entities.SeasonProgress, *entities.RewardGrant, error)
+	ListAchievements(ctx context.Context, userID string) ([]entities.UserAchievement, error)
+	GetRoomProgress(ctx context.Context, taskID string, roomID string) (*entities.RoomProgress, error)
+	JoinGroup(ctx context.Context, userID string, groupID string) (*entities.GroupMember, error)
+	LeaveGroup(ctx context.Context, userID string, groupID string) error
+	ListGroupMembers(ctx context.Context, groupID string) (*entities.Group, []entities.GroupMember, error)
+	GetGroupProgress(ctx context.Context, taskID string, groupID string) (*entities.RoomProgress, error)
+	GetLeaderboard(ctx context.Context, taskID string, periodKey string, limit int) (*entities.Leaderboard, error)
+	GetLeaderboardAroundUser(ctx context.Context, taskID string, userID string, periodKey string, radius int) (*entities.Leaderboard, error)
+	GetBalances(ctx context.Context, userID string) ([]entities.Balance, error)
+	ListLedger(ctx context.Context, userID string, pageToken string, limit int) ([]*entities.LedgerTransaction, string, error)
+	SpendCurrency(ctx context.Context, userID string, currency string, amount int64, reference string) (*entities.LedgerTransaction, entities.Balance, error)
+}
+
+type TaskAdminUseCases interface {
+	CreateTask(ctx context.Context, task *entities.Task) (*entities.Task, error)
+	UpdateTask(ctx context.Context, task *entities.Task) (*entities.Task, error)
+	DeactivateTask(ctx context.Context, taskID string) (*entities.Task, error)
+	ListTasks(ctx context.Context, includeInactive bool) ([]*entities.Task, error)
+	CreateSeason(ctx context.Context, season *entities.Season) (*entities.Season, error)
+	SetSeasonPremium(ctx context.Context, userID string, seasonID string, premium bool) (*entities.Season, *entities.SeasonProgress, error)
+	CreateAchievement(ctx context.Context, achievement *entities.Achievement) (*entities.Achievement, error)
+	CreateGroup(ctx context.Context, group *entities.Group) (*entities.Group, error)
 }
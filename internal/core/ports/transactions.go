@@ -3,9 +3,19 @@ package ports
 import "context"
 
 type Repositories struct {
-	Tasks    TaskRepository
-	Progress ProgressRepository
-	Events   EventRepository
+	Tasks        TaskRepository
+	Progress     ProgressRepository
+	Events       EventRepository
+	Users        UserRepository
+	Sessions     SessionRepository
+	Seasons      SeasonRepository
+	Rooms        RoomRepository
+	Groups       GroupRepository
+	Leaderboards LeaderboardRepository
+	Achievements AchievementRepository
+	RewardOutbox RewardOutboxRepository
+	Ledger       LedgerRepository
+	RewardClaims RewardClaimRepository
 }
 
 type UnitOfWork interface {
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+// ListAchievements returns every achievement with the time the user unlocked
+// it; locked achievements have a zero unlock time.
+func (s *TaskService) ListAchievements(ctx context.Context, userID string) ([]entities.UserAchievement, error) {
+	s.log.Debug("usecase: list achievements", zap.String("user_id", userID))
+	achievements, err := s.achievements.List(ctx)
+	if err != nil {
+		s.log.Warn("usecase: list achievements failed", zap.Error(err))
+		return nil, err
+	}
+
+	unlocked, err := s.achievements.ListUnlocked(ctx, userID)
+	if err != nil {
+		s.log.Warn("usecase: list achievements failed", zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]entities.UserAchievement, 0, len(achievements))
+	for _, achievement := range achievements {
+		result = append(result, entities.UserAchievement{
+			Achievement: achievement,
+			UnlockedAt:  unlocked[achievement.ID()],
+		})
+	}
+	s.log.Debug("usecase: list achievements done", zap.Int("count", len(result)), zap.Int("unlocked", len(unlocked)))
+	return result, nil
+}
+
+func (s *TaskService) CreateAchievement(ctx context.Context, achievement *entities.Achievement) (*entities.Achievement, error) {
+	s.log.Info("usecase: create achievement", zap.String("title", achievement.Title()))
+	if err := achievement.Validate(); err != nil {
+		s.log.Warn("usecase: create achievement validation failed", zap.Error(err))
+		return nil, err
+	}
+	if err := s.checkLedgerRewards(achievement.Reward()); err != nil {
+		s.log.Warn("usecase: create achievement validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.achievements.Create(ctx, achievement); err != nil {
+		s.log.Warn("usecase: create achievement failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: create achievement done", zap.String("achievement_id", achievement.ID()))
+	return achievement, nil
+}
+
+// recordCompletion counts the first completion of a task towards the user's
+// lifetime completion counters. Later periods and repetitions do not count, so
+// the counters hold the number of distinct tasks the user has completed.
+func (s *TaskService) recordCompletion(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task) error {
+	first, err := repos.Achievements.MarkTaskCompleted(ctx, userID, task.ID(), s.now())
+	if err != nil || !first {
+		return err
+	}
+	return s.bumpCounters(ctx, repos, userID,
+		entities.CounterTasksCompleted,
+		entities.QualifiedCounter(entities.CounterTasksCompleted, string(task.Type())),
+	)
+}
+
+// bumpCounters increments the user's lifetime counters by one and unlocks the
+// achievements that depend on them and are now met. The reward of an unlocked
+// achievement is granted in the same transaction.
+func (s *TaskService) bumpCounters(ctx context.Context, repos ports.Repositories, userID string, counters ...string) error {
+	now := s.now()
+	if err := repos.Achievements.IncrementCounters(ctx, userID, counters, now); err != nil {
+		return err
+	}
+
+	achievements, err := repos.Achievements.List(ctx)
+	if err != nil {
+		return err
+	}
+	candidates := make([]*entities.Achievement, 0, len(achievements))
+	for _, achievement := range achievements {
+		if achievement.DependsOn(counters) {
+			candidates = append(candidates, achievement)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	unlocked, err := repos.Achievements.ListUnlocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	pending := candidates[:0]
+	for _, achievement := range candidates {
+		if _, ok := unlocked[achievement.ID()]; !ok {
+			pending = append(pending, achievement)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	values, err := repos.Achievements.GetCounters(ctx, userID)
+	if err != nil {
+		return err
+	}
+	left, err := s.tasksLeft(ctx, repos, userID, pending)
+	if err != nil {
+		return err
+	}
+	for _, achievement := range pending {
+		if !achievement.IsMet(values, left) {
+			continue
+		}
+		first, err := repos.Achievements.Unlock(ctx, userID, achievement.ID(), now)
+		if err != nil {
+			return err
+		}
+		if !first {
+			continue
+		}
+		if reward := achievement.Reward(); len(reward) > 0 {
+			grant := entities.NewAchievementRewardGrant(userID, achievement.ID(), reward)
+			if err := s.enqueueGrant(ctx, repos, grant); err != nil {
+				return err
+			}
+		}
+		s.log.Info("usecase: achievement unlocked", zap.String("user_id", userID), zap.String("achievement_id", achievement.ID()))
+	}
+	return nil
+}
+
+// tasksLeft counts the active tasks required by all_tasks criteria that the
+// user has not completed, keyed by the completion counter each criterion
+// reads. Completed tasks are matched by ID, so deactivated tasks the user
+// completed earlier do not stand in for active ones.
+func (s *TaskService) tasksLeft(ctx context.Context, repos ports.Repositories, userID string, achievements []*entities.Achievement) (map[string]int64, error) {
+	needed := false
+	for _, achievement := range achievements {
+		for _, criterion := range achievement.Criteria() {
+			if criterion.Kind == entities.CriterionAllTasks {
+				needed = true
+			}
+		}
+	}
+	if !needed {
+		return nil, nil
+	}
+
+	tasks, err := repos.Tasks.ListActive(ctx, s.now())
+	if err != nil {
+		return nil, err
+	}
+	completed, err := repos.Achievements.ListCompletedTasks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	left := make(map[string]int64)
+	for _, task := range tasks {
+		missing := int64(1)
+		if _, ok := completed[task.ID()]; ok {
+			missing = 0
+		}
+		left[entities.CounterTasksCompleted] += missing
+		left[entities.QualifiedCounter(entities.CounterTasksCompleted, string(task.Type()))] += missing
+	}
+	return left, nil
+}
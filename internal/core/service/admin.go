@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+func (s *TaskService) CreateTask(ctx context.Context, task *entities.Task) (*entities.Task, error) {
+	s.log.Info("usecase: create task", zap.String("title", task.Title()), zap.String("type", string(task.Type())))
+	if err := task.Validate(); err != nil {
+		s.log.Warn("usecase: create task validation failed", zap.Error(err))
+		return nil, err
+	}
+	if err := s.checkLedgerRewards(taskRewards(task)...); err != nil {
+		s.log.Warn("usecase: create task validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		if err := s.checkPrerequisites(ctx, repos, task); err != nil {
+			return err
+		}
+		if err := s.checkQuest(ctx, repos, task); err != nil {
+			return err
+		}
+		if err := s.checkStreak(ctx, repos, task); err != nil {
+			return err
+		}
+		if err := repos.Tasks.Create(ctx, task); err != nil {
+			return err
+		}
+		if err := repos.Tasks.SetPrerequisites(ctx, task.ID(), task.Prerequisites()); err != nil {
+			return err
+		}
+		return repos.Tasks.SetChildren(ctx, task.ID(), task.Children())
+	})
+	if err != nil {
+		s.log.Warn("usecase: create task failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: create task done", zap.String("task_id", task.ID()))
+	return task, nil
+}
+
+func (s *TaskService) UpdateTask(ctx context.Context, task *entities.Task) (*entities.Task, error) {
+	s.log.Info("usecase: update task", zap.String("task_id", task.ID()))
+	if err := task.Validate(); err != nil {
+		s.log.Warn("usecase: update task validation failed", zap.Error(err))
+		return nil, err
+	}
+	if err := s.checkLedgerRewards(taskRewards(task)...); err != nil {
+		s.log.Warn("usecase: update task validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		if err := s.checkPrerequisites(ctx, repos, task); err != nil {
+			return err
+		}
+		if err := s.checkQuest(ctx, repos, task); err != nil {
+			return err
+		}
+		if err := s.checkStreak(ctx, repos, task); err != nil {
+			return err
+		}
+		if err := s.checkShared(ctx, repos, task); err != nil {
+			return err
+		}
+		if err := repos.Tasks.Update(ctx, task); err != nil {
+			return err
+		}
+		if err := repos.Tasks.SetPrerequisites(ctx, task.ID(), task.Prerequisites()); err != nil {
+			return err
+		}
+		return repos.Tasks.SetChildren(ctx, task.ID(), task.Children())
+	})
+	if err != nil {
+		s.log.Warn("usecase: update task failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: update task done", zap.String("task_id", task.ID()))
+	return task, nil
+}
+
+func (s *TaskService) DeactivateTask(ctx context.Context, taskID string) (*entities.Task, error) {
+	s.log.Info("usecase: deactivate task", zap.String("task_id", taskID))
+	var task *entities.Task
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		if err := repos.Tasks.Deactivate(ctx, taskID); err != nil {
+			return err
+		}
+
+		var err error
+		task, err = repos.Tasks.GetByID(ctx, taskID)
+		return err
+	})
+	if err != nil {
+		s.log.Warn("usecase: deactivate task failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: deactivate task done", zap.String("task_id", taskID))
+	return task, nil
+}
+
+func (s *TaskService) ListTasks(ctx context.Context, includeInactive bool) ([]*entities.Task, error) {
+	s.log.Debug("usecase: list tasks", zap.Bool("include_inactive", includeInactive))
+	var (
+		tasks []*entities.Task
+		err   error
+	)
+	if includeInactive {
+		tasks, err = s.tasks.ListAll(ctx)
+	} else {
+		tasks, err = s.tasks.ListActive(ctx, s.now())
+	}
+	if err != nil {
+		s.log.Warn("usecase: list tasks failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Debug("usecase: list tasks done", zap.Int("tasks", len(tasks)))
+	return tasks, nil
+}
+
+// checkPrerequisites rejects prerequisites that point to unknown tasks or would
+// close a cycle once the task is saved.
+func (s *TaskService) checkPrerequisites(ctx context.Context, repos ports.Repositories, task *entities.Task) error {
+	if len(task.Prerequisites()) == 0 {
+		return nil
+	}
+
+	tasks, err := repos.Tasks.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	graph := make(map[string][]string, len(tasks)+1)
+	for _, existing := range tasks {
+		graph[existing.ID()] = existing.Prerequisites()
+	}
+	graph[task.ID()] = task.Prerequisites()
+
+	return entities.CheckPrerequisiteGraph(graph)
+}
+
+// checkQuest rejects quest children that point to unknown tasks or would make
+// a quest contain itself once the task is saved.
+func (s *TaskService) checkQuest(ctx context.Context, repos ports.Repositories, task *entities.Task) error {
+	if !task.IsQuest() {
+		return nil
+	}
+
+	tasks, err := repos.Tasks.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	graph := make(map[string][]string, len(tasks)+1)
+	for _, existing := range tasks {
+		graph[existing.ID()] = existing.Children()
+	}
+	graph[task.ID()] = task.Children()
+
+	if err := entities.CheckQuestGraph(graph); err != nil {
+		return err
+	}
+	for _, existing := range tasks {
+		if existing.IsShared() && slices.Contains(task.Children(), existing.ID()) {
+			return exceptions.ErrTaskSharedSourceInvalid
+		}
+	}
+	return nil
+}
+
+// checkShared keeps a task that quests or streaks already build on from
+// becoming a room or group task, since shared progress is not the user's own.
+func (s *TaskService) checkShared(ctx context.Context, repos ports.Repositories, task *entities.Task) error {
+	if !task.IsShared() {
+		return nil
+	}
+
+	existing, err := repos.Tasks.GetByID(ctx, task.ID())
+	if err != nil {
+		return err
+	}
+	if len(existing.QuestIDs()) > 0 || len(existing.StreakIDs()) > 0 {
+		return exceptions.ErrTaskSharedSourceInvalid
+	}
+	return nil
+}
+
+// checkStreak requires every task tracked by a streak to exist, reset daily
+// and not be a streak itself.
+func (s *TaskService) checkStreak(ctx context.Context, repos ports.Repositories, task *entities.Task) error {
+	config := task.Streak()
+	if config == nil {
+		return nil
+	}
+
+	for _, taskID := range config.TaskIDs {
+		source, err := repos.Tasks.GetByID(ctx, taskID)
+		if err != nil {
+			if errors.Is(err, exceptions.ErrTaskNotFound) {
+				return exceptions.ErrTaskStreakSourceInvalid
+			}
+			return err
+		}
+		if source.IsStreak() || source.ResetPeriod() != entities.ResetPeriodDaily {
+			return exceptions.ErrTaskStreakSourceInvalid
+		}
+		if source.IsShared() {
+			return exceptions.ErrTaskSharedSourceInvalid
+		}
+	}
+	return nil
+}
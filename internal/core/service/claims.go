@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+// ClaimAllRewards claims every reward the user can claim on per-user tasks in
+// one transaction: all reached tiers of tiered tasks, including those of tasks
+// whose final tier is not reached yet, and all completed repetitions of
+// repeatable tasks. Like ClaimReward it only claims in the current period, so
+// rows of past periods are skipped. It returns
+// the claims per task and their combined reward.
+func (s *TaskService) ClaimAllRewards(ctx context.Context, userID string) ([]entities.TaskClaims, *entities.Reward, error) {
+	s.log.Info("usecase: claim all rewards", zap.String("user_id", userID))
+	var results []entities.TaskClaims
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+		results = nil
+
+		claimable, err := repos.Progress.ListClaimable(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(claimable) == 0 {
+			return nil
+		}
+
+		loc, err := s.userLocation(ctx, repos.Users, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, progress := range claimable {
+			task, err := repos.Tasks.GetByID(ctx, progress.TaskID())
+			if err != nil {
+				return err
+			}
+			if task.IsShared() || progress.PeriodKey() != entities.PeriodKey(task.ResetPeriod(), s.now(), loc) {
+				continue
+			}
+
+			claims, err := s.claimAllOnTask(ctx, repos, userID, task, loc)
+			if err != nil {
+				return err
+			}
+			if len(claims) == 0 {
+				continue
+			}
+			current, err := s.claimedProgress(ctx, repos, task, claims[len(claims)-1], loc)
+			if err != nil {
+				return err
+			}
+			results = append(results, entities.TaskClaims{TaskID: task.ID(), Claims: claims, Progress: current})
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Warn("usecase: claim all rewards failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	rewards := make([]*entities.Reward, 0, len(results))
+	for _, result := range results {
+		rewards = append(rewards, result.Reward())
+	}
+	s.log.Info("usecase: claim all rewards done", zap.String("user_id", userID), zap.Int("tasks", len(results)))
+	return results, entities.MergeRewards(rewards...), nil
+}
+
+// claimAllOnTask claims the task until nothing is left to claim. Each tier and
+// each repetition is claimed at most once, which bounds the loop.
+func (s *TaskService) claimAllOnTask(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task, loc *time.Location) ([]*entities.RewardClaim, error) {
+	limit := len(task.Tiers())
+	if task.IsRepeatable() {
+		limit = task.RepeatLimit()
+	}
+
+	claims := make([]*entities.RewardClaim, 0, limit)
+	for range limit {
+		claim, err := s.claimTask(ctx, repos, userID, task, 0, loc)
+		if err != nil {
+			if errors.Is(err, exceptions.ErrRewardAlreadyClaimed) || errors.Is(err, exceptions.ErrTaskNotCompleted) {
+				break
+			}
+			return nil, err
+		}
+		if err := s.applyClaim(ctx, repos, task, claim); err != nil {
+			return nil, err
+		}
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
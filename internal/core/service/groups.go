@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+func (s *TaskService) CreateGroup(ctx context.Context, group *entities.Group) (*entities.Group, error) {
+	s.log.Info("usecase: create group", zap.String("name", group.Name()))
+	if err := group.Validate(); err != nil {
+		s.log.Warn("usecase: create group validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.groups.Create(ctx, group); err != nil {
+		s.log.Warn("usecase: create group failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: create group done", zap.String("group_id", group.ID()))
+	return group, nil
+}
+
+// JoinGroup adds the user to the group. Users belong to one group at a time
+// and must leave their group before joining another.
+func (s *TaskService) JoinGroup(ctx context.Context, userID string, groupID string) (*entities.GroupMember, error) {
+	s.log.Info("usecase: join group", zap.String("user_id", userID), zap.String("group_id", groupID))
+	var member *entities.GroupMember
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		if _, err := repos.Groups.GetByID(ctx, groupID); err != nil {
+			return err
+		}
+		var err error
+		member, err = repos.Groups.Join(ctx, groupID, userID, s.now())
+		return err
+	})
+	if err != nil {
+		s.log.Warn("usecase: join group failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: join group done", zap.String("user_id", userID), zap.String("group_id", groupID))
+	return member, nil
+}
+
+// LeaveGroup removes the user from the group. What the user already
+// contributed stays with the group and remains claimable by the user.
+func (s *TaskService) LeaveGroup(ctx context.Context, userID string, groupID string) error {
+	s.log.Info("usecase: leave group", zap.String("user_id", userID), zap.String("group_id", groupID))
+	if err := s.groups.Leave(ctx, groupID, userID); err != nil {
+		s.log.Warn("usecase: leave group failed", zap.Error(err))
+		return err
+	}
+	s.log.Info("usecase: leave group done", zap.String("user_id", userID), zap.String("group_id", groupID))
+	return nil
+}
+
+func (s *TaskService) ListGroupMembers(ctx context.Context, groupID string) (*entities.Group, []entities.GroupMember, error) {
+	s.log.Debug("usecase: list group members", zap.String("group_id", groupID))
+	group, err := s.groups.GetByID(ctx, groupID)
+	if err != nil {
+		s.log.Warn("usecase: list group members failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	members, err := s.groups.ListMembers(ctx, groupID)
+	if err != nil {
+		s.log.Warn("usecase: list group members failed", zap.Error(err))
+		return nil, nil, err
+	}
+	s.log.Debug("usecase: list group members done", zap.Int("count", len(members)))
+	return group, members, nil
+}
+
+// GetGroupProgress returns the group's shared progress on a group task in the
+// current period, with what each member contributed.
+func (s *TaskService) GetGroupProgress(ctx context.Context, taskID string, groupID string) (*entities.RoomProgress, error) {
+	s.log.Debug("usecase: get group progress", zap.String("task_id", taskID), zap.String("group_id", groupID))
+	progress, err := s.sharedProgress(ctx, taskID, groupID, entities.TaskScopeGroup)
+	if err != nil {
+		s.log.Warn("usecase: get group progress failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Debug("usecase: get group progress done", zap.Int("progress", progress.Progress), zap.Int("contributors", len(progress.Contributions)))
+	return progress, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLeaderboardLimit  = 10
+	defaultLeaderboardRadius = 5
+)
+
+// GetLeaderboard returns the top of a task leaderboard. An empty period key
+// selects the current bucket.
+func (s *TaskService) GetLeaderboard(ctx context.Context, taskID string, periodKey string, limit int) (*entities.Leaderboard, error) {
+	s.log.Debug("usecase: get leaderboard", zap.String("task_id", taskID), zap.String("period_key", periodKey), zap.Int("limit", limit))
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+
+	board, err := s.leaderboard(ctx, taskID, periodKey)
+	if err != nil {
+		s.log.Warn("usecase: get leaderboard failed", zap.Error(err))
+		return nil, err
+	}
+	board.Entries, err = s.leaderboards.Top(ctx, taskID, board.PeriodKey, limit)
+	if err != nil {
+		s.log.Warn("usecase: get leaderboard failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Debug("usecase: get leaderboard done", zap.String("period_key", board.PeriodKey), zap.Int("entries", len(board.Entries)))
+	return board, nil
+}
+
+// GetLeaderboardAroundUser returns the user's entry with up to radius entries
+// ranked right above and below it. Users without a score get no entries.
+func (s *TaskService) GetLeaderboardAroundUser(ctx context.Context, taskID string, userID string, periodKey string, radius int) (*entities.Leaderboard, error) {
+	s.log.Debug("usecase: get leaderboard around user", zap.String("task_id", taskID), zap.String("user_id", userID), zap.String("period_key", periodKey), zap.Int("radius", radius))
+	if radius <= 0 {
+		radius = defaultLeaderboardRadius
+	}
+
+	board, err := s.leaderboard(ctx, taskID, periodKey)
+	if err != nil {
+		s.log.Warn("usecase: get leaderboard around user failed", zap.Error(err))
+		return nil, err
+	}
+	board.Entries, err = s.leaderboards.Around(ctx, taskID, board.PeriodKey, userID, radius)
+	if err != nil && !errors.Is(err, exceptions.ErrProgressNotFound) {
+		s.log.Warn("usecase: get leaderboard around user failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Debug("usecase: get leaderboard around user done", zap.String("period_key", board.PeriodKey), zap.Int("entries", len(board.Entries)))
+	return board, nil
+}
+
+// leaderboard resolves the bucket of a ranked task and counts its users.
+func (s *TaskService) leaderboard(ctx context.Context, taskID string, periodKey string) (*entities.Leaderboard, error) {
+	task, err := s.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	config := task.Leaderboard()
+	if config == nil {
+		return nil, exceptions.ErrLeaderboardDisabled
+	}
+	if periodKey == "" {
+		periodKey = entities.PeriodKey(config.Period, s.now(), s.location)
+	}
+
+	total, err := s.leaderboards.Count(ctx, taskID, periodKey)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.Leaderboard{
+		TaskID:    taskID,
+		PeriodKey: periodKey,
+		Total:     total,
+	}, nil
+}
+
+// recordLeaderboard feeds the value of a progress update into the task's
+// current leaderboard bucket.
+func (s *TaskService) recordLeaderboard(ctx context.Context, repos ports.Repositories, task *entities.Task, userID string, value int) error {
+	config := task.Leaderboard()
+	if config == nil {
+		return nil
+	}
+
+	now := s.now()
+	periodKey := entities.PeriodKey(config.Period, now, s.location)
+	return repos.Leaderboards.Record(ctx, task.ID(), periodKey, userID, value, task.Aggregation().Mode, task.Target(), now)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+const defaultLedgerLimit = 50
+
+// creditReward credits the reward's currencies and items to the user's ledger;
+// reference is the grant ID. The ledger holds no XP or bundles.
+func (s *TaskService) creditReward(ctx context.Context, repos ports.Repositories, userID string, reference string, reward *entities.Reward) error {
+	tx := entities.NewRewardCredit(userID, reference, reward)
+	if tx == nil {
+		return nil
+	}
+	tx.CreatedAt = s.now()
+	return repos.Ledger.Post(ctx, tx)
+}
+
+// GetBalances returns the user's currency balances and item inventory.
+func (s *TaskService) GetBalances(ctx context.Context, userID string) ([]entities.Balance, error) {
+	s.log.Debug("usecase: get balances", zap.String("user_id", userID))
+	balances, err := s.ledger.ListBalances(ctx, userID)
+	if err != nil {
+		s.log.Warn("usecase: get balances failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Debug("usecase: get balances done", zap.Int("balances", len(balances)))
+	return balances, nil
+}
+
+// ListLedger returns a page of the user's ledger history, newest first, and the
+// token of the next page, which is empty on the last page.
+func (s *TaskService) ListLedger(ctx context.Context, userID string, pageToken string, limit int) ([]*entities.LedgerTransaction, string, error) {
+	s.log.Debug("usecase: list ledger", zap.String("user_id", userID), zap.String("page_token", pageToken), zap.Int("limit", limit))
+	if limit <= 0 {
+		limit = defaultLedgerLimit
+	}
+
+	transactions, err := s.ledger.ListTransactions(ctx, userID, pageToken, limit)
+	if err != nil {
+		s.log.Warn("usecase: list ledger failed", zap.Error(err))
+		return nil, "", err
+	}
+	nextPageToken := ""
+	if len(transactions) == limit {
+		nextPageToken = transactions[len(transactions)-1].ID
+	}
+	s.log.Debug("usecase: list ledger done", zap.Int("transactions", len(transactions)))
+	return transactions, nextPageToken, nil
+}
+
+// SpendCurrency debits amount of currency from the user and returns the
+// resulting balance. Reference identifies the purchase; reusing it fails with
+// ErrLedgerReferenceExists so retried requests are not charged twice.
+func (s *TaskService) SpendCurrency(ctx context.Context, userID string, currency string, amount int64, reference string) (*entities.LedgerTransaction, entities.Balance, error) {
+	s.log.Info("usecase: spend currency", zap.String("user_id", userID), zap.String("currency", currency), zap.Int64("amount", amount), zap.String("reference", reference))
+	tx, err := entities.NewSpend(userID, currency, amount, reference)
+	if err != nil {
+		s.log.Warn("usecase: spend currency validation failed", zap.Error(err))
+		return nil, entities.Balance{}, err
+	}
+	tx.CreatedAt = s.now()
+
+	var balance entities.Balance
+	err = s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+		if err := repos.Ledger.Post(ctx, tx); err != nil {
+			return err
+		}
+		balance, err = repos.Ledger.GetBalance(ctx, userID, entities.AssetCurrency, currency)
+		return err
+	})
+	if err != nil {
+		s.log.Warn("usecase: spend currency failed", zap.Error(err))
+		return nil, entities.Balance{}, err
+	}
+	s.log.Info("usecase: spend currency done", zap.String("transaction_id", tx.ID), zap.Int64("balance", balance.Amount))
+	return tx, balance, nil
+}
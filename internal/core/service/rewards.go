@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+// grantReward records the claimed reward in the outbox in the claim's
+// transaction, so a claim is never committed without its reward. Claims
+// without a reward have nothing to grant.
+func (s *TaskService) grantReward(ctx context.Context, repos ports.Repositories, claim *entities.RewardClaim) error {
+	if len(claim.Reward) == 0 {
+		return nil
+	}
+	grant := claim.Grant()
+	if err := s.enqueueGrant(ctx, repos, grant); err != nil {
+		return err
+	}
+	claim.GrantID = grant.ID
+	return nil
+}
+
+// enqueueGrant stores the grant in the outbox. With the built-in ledger the
+// reward is credited right away and the grant is stored as delivered, so a
+// reward the ledger cannot credit in full fails the grant instead of waiting
+// for a dispatcher that does not run; otherwise the grant waits for the
+// dispatcher to deliver it to the reward sink.
+func (s *TaskService) enqueueGrant(ctx context.Context, repos ports.Repositories, grant *entities.RewardGrant) error {
+	if !s.ledgerRewards {
+		return repos.RewardOutbox.Enqueue(ctx, grant)
+	}
+
+	reward, err := ledgerReward(grant.Reward)
+	if err != nil {
+		s.log.Warn("usecase: reward not credited to ledger", zap.String("source", string(grant.Source)), zap.String("source_id", grant.SourceID), zap.Error(err))
+		return err
+	}
+	grant.MarkDelivered(s.now())
+	if err := repos.RewardOutbox.Enqueue(ctx, grant); err != nil {
+		return err
+	}
+	return s.creditReward(ctx, repos, grant.UserID, grant.ID, reward)
+}
+
+// ledgerReward parses a reward the ledger must credit in full. Rewards that
+// predate the reward schema and rewards holding XP or bundles are rejected.
+func ledgerReward(raw json.RawMessage) (*entities.Reward, error) {
+	reward, err := entities.ParseReward(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !reward.LedgerOnly() {
+		return nil, exceptions.ErrLedgerRewardUnsupported
+	}
+	return reward, nil
+}
+
+// checkLedgerRewards rejects rewards the ledger cannot credit when it is the
+// reward sink, as nothing else would deliver them.
+func (s *TaskService) checkLedgerRewards(rewards ...json.RawMessage) error {
+	if !s.ledgerRewards {
+		return nil
+	}
+	for _, reward := range rewards {
+		if _, err := ledgerReward(reward); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckLedgerRewards reports rewards that can never be delivered with the
+// ledger as the reward sink: grants left pending by another sink, which no
+// dispatcher drains, and stored tasks, seasons and achievements with rewards
+// the ledger cannot credit.
+func (s *TaskService) CheckLedgerRewards(ctx context.Context) error {
+	if !s.ledgerRewards {
+		return nil
+	}
+
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		pending, err := repos.RewardOutbox.CountPending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d reward grants are pending and no dispatcher runs with the ledger sink", pending)
+		}
+
+		tasks, err := repos.Tasks.ListAll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			if err := s.checkLedgerRewards(taskRewards(task)...); err != nil {
+				return fmt.Errorf("task %s: %w", task.ID(), err)
+			}
+		}
+
+		seasons, err := repos.Seasons.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, season := range seasons {
+			if err := s.checkLedgerRewards(seasonRewards(season)...); err != nil {
+				return fmt.Errorf("season %s: %w", season.ID(), err)
+			}
+		}
+
+		achievements, err := repos.Achievements.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, achievement := range achievements {
+			if err := s.checkLedgerRewards(achievement.Reward()); err != nil {
+				return fmt.Errorf("achievement %s: %w", achievement.ID(), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Warn("usecase: check ledger rewards failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func taskRewards(task *entities.Task) []json.RawMessage {
+	tiers := task.Tiers()
+	rewards := make([]json.RawMessage, 0, len(tiers))
+	for _, tier := range tiers {
+		rewards = append(rewards, tier.Reward)
+	}
+	return rewards
+}
+
+func seasonRewards(season *entities.Season) []json.RawMessage {
+	levels := season.Levels()
+	rewards := make([]json.RawMessage, 0, 2*len(levels))
+	for _, level := range levels {
+		rewards = append(rewards, level.FreeReward, level.PremiumReward)
+	}
+	return rewards
+}
+
+type RewardDispatcherConfig struct {
+	Interval    time.Duration
+	BatchSize   int
+	Lease       time.Duration
+	MaxAttempts int
+	RetryDelay  time.Duration
+	MaxDelay    time.Duration
+}
+
+// RewardDispatcher delivers pending outbox grants to the reward sink. Failed
+// deliveries are retried with exponential backoff until MaxAttempts is used up.
+type RewardDispatcher struct {
+	outbox ports.RewardOutboxRepository
+	sink   ports.RewardSink
+	cfg    RewardDispatcherConfig
+	now    func() time.Time
+	log    *zap.Logger
+}
+
+func NewRewardDispatcher(outbox ports.RewardOutboxRepository, sink ports.RewardSink, cfg RewardDispatcherConfig, log *zap.Logger) (*RewardDispatcher, error) {
+	if outbox == nil {
+		return nil, errors.New("reward outbox is nil")
+	}
+	if sink == nil {
+		return nil, errors.New("reward sink is nil")
+	}
+	if cfg.Interval <= 0 || cfg.BatchSize <= 0 || cfg.Lease <= 0 {
+		return nil, errors.New("reward dispatch interval, batch size and lease must be positive")
+	}
+	if cfg.MaxAttempts <= 0 || cfg.RetryDelay <= 0 || cfg.MaxDelay < cfg.RetryDelay {
+		return nil, errors.New("reward retry attempts and delays must be positive")
+	}
+	if log == nil {
+		return nil, errors.New("logger is nil")
+	}
+	return &RewardDispatcher{
+		outbox: outbox,
+		sink:   sink,
+		cfg:    cfg,
+		now:    time.Now,
+		log:    log,
+	}, nil
+}
+
+// Run dispatches due grants every interval until ctx is done.
+func (d *RewardDispatcher) Run(ctx context.Context) {
+	d.log.Info("usecase: reward dispatcher started", zap.Duration("interval", d.cfg.Interval))
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.log.Info("usecase: reward dispatcher stopped")
+			return
+		case <-ticker.C:
+			d.dispatchDue(ctx)
+		}
+	}
+}
+
+// dispatchDue drains the due grants batch by batch.
+func (d *RewardDispatcher) dispatchDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		dispatched, err := d.Dispatch(ctx)
+		if err != nil || dispatched < d.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// Dispatch delivers one batch of due grants and returns how many it attempted.
+func (d *RewardDispatcher) Dispatch(ctx context.Context) (int, error) {
+	now := d.now()
+	grants, err := d.outbox.Lease(ctx, now, now.Add(d.cfg.Lease), d.cfg.BatchSize)
+	if err != nil {
+		d.log.Warn("usecase: lease reward grants failed", zap.Error(err))
+		return 0, err
+	}
+
+	for _, grant := range grants {
+		d.deliver(ctx, grant)
+	}
+	if len(grants) > 0 {
+		d.log.Debug("usecase: reward grants dispatched", zap.Int("count", len(grants)))
+	}
+	return len(grants), nil
+}
+
+func (d *RewardDispatcher) deliver(ctx context.Context, grant *entities.RewardGrant) {
+	if err := d.sink.Deliver(ctx, grant); err != nil {
+		grant.MarkAttemptFailed(err.Error(), d.now().Add(d.retryDelay(grant.Attempts+1)), d.cfg.MaxAttempts)
+		if grant.Status == entities.RewardDeliveryFailed {
+			d.log.Error("usecase: reward delivery gave up",
+				zap.String("grant_id", grant.ID),
+				zap.String("user_id", grant.UserID),
+				zap.Int("attempts", grant.Attempts),
+				zap.Error(err),
+			)
+		} else {
+			d.log.Warn("usecase: reward delivery failed",
+				zap.String("grant_id", grant.ID),
+				zap.Int("attempts", grant.Attempts),
+				zap.Time("next_attempt_at", grant.NextAttemptAt),
+				zap.Error(err),
+			)
+		}
+	} else {
+		grant.MarkDelivered(d.now())
+	}
+
+	// A save that fails leaves the grant leased; it is delivered again once the
+	// lease runs out, which sinks absorb through the grant ID.
+	if err := d.outbox.SaveDelivery(ctx, grant); err != nil {
+		d.log.Warn("usecase: save reward delivery failed", zap.String("grant_id", grant.ID), zap.Error(err))
+	}
+}
+
+// retryDelay doubles the base delay for every attempt made, up to MaxDelay.
+func (d *RewardDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.cfg.RetryDelay
+	for i := 1; i < attempts && delay < d.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxDelay)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+// GetRoomProgress returns the room's shared progress on a room task in the
+// current period, with what each member contributed.
+func (s *TaskService) GetRoomProgress(ctx context.Context, taskID string, roomID string) (*entities.RoomProgress, error) {
+	s.log.Debug("usecase: get room progress", zap.String("task_id", taskID), zap.String("room_id", roomID))
+	progress, err := s.sharedProgress(ctx, taskID, roomID, entities.TaskScopeRoom)
+	if err != nil {
+		s.log.Warn("usecase: get room progress failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Debug("usecase: get room progress done", zap.Int("progress", progress.Progress), zap.Int("contributors", len(progress.Contributions)))
+	return progress, nil
+}
+
+// sharedProgress returns the current period progress of a room or group on a
+// task of the given scope; teamID is the room or group ID.
+func (s *TaskService) sharedProgress(ctx context.Context, taskID string, teamID string, scope entities.TaskScope) (*entities.RoomProgress, error) {
+	task, err := s.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Scope() != scope {
+		return nil, exceptions.ErrTaskScopeInvalid
+	}
+
+	periodKey := entities.PeriodKey(task.ResetPeriod(), s.now(), s.location)
+	progress, err := s.rooms.Get(ctx, taskID, teamID, periodKey)
+	if err != nil {
+		if !errors.Is(err, exceptions.ErrProgressNotFound) {
+			return nil, err
+		}
+		progress = &entities.RoomProgress{
+			TaskID:    taskID,
+			RoomID:    teamID,
+			PeriodKey: periodKey,
+			Target:    task.Target(),
+		}
+	}
+	return progress, nil
+}
+
+// addSharedProgress adds the event to the shared counter of the event's room,
+// or of the group the user belongs to now. When it completes the counter, the
+// task counts as completed for every member who contributed.
+func (s *TaskService) addSharedProgress(ctx context.Context, repos ports.Repositories, task *entities.Task, event *entities.TaskEvent) error {
+	teamID, err := s.contributionTeam(ctx, repos, task, event)
+	if err != nil {
+		return err
+	}
+	value, err := task.Aggregation().NumericValue(event)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	periodKey := entities.PeriodKey(task.ResetPeriod(), now, s.location)
+	completed, err := repos.Rooms.AddProgress(ctx, task.ID(), teamID, periodKey, event.UserID(), value, task.Target(), now)
+	if err != nil || !completed {
+		return err
+	}
+
+	room, err := repos.Rooms.Get(ctx, task.ID(), teamID, periodKey)
+	if err != nil {
+		return err
+	}
+	for _, contribution := range room.Contributions {
+		if err := s.recordCompletion(ctx, repos, contribution.UserID, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contributionTeam returns the room or group the event's progress goes to.
+func (s *TaskService) contributionTeam(ctx context.Context, repos ports.Repositories, task *entities.Task, event *entities.TaskEvent) (string, error) {
+	if task.IsGroupScoped() {
+		groupID, err := repos.Groups.GetUserGroup(ctx, event.UserID())
+		if errors.Is(err, exceptions.ErrGroupMemberNotFound) {
+			return "", exceptions.ErrGroupMembershipRequired
+		}
+		return groupID, err
+	}
+	if event.RoomID() == "" {
+		return "", exceptions.ErrEventRoomRequired
+	}
+	return event.RoomID(), nil
+}
+
+// claimSharedTask claims the user's share of a completed room or group task,
+// even after the user left the group, and returns the room or group ID.
+func (s *TaskService) claimSharedTask(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task, periodKey string) (string, error) {
+	roomID, err := repos.Rooms.FindUserRoom(ctx, userID, task.ID(), periodKey)
+	if err != nil {
+		return "", err
+	}
+	if err := repos.Rooms.Claim(ctx, userID, task.ID(), roomID, periodKey); err != nil {
+		return "", err
+	}
+	return roomID, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/domain/exceptions"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+// GetSeason returns the season running now and the user's progress in it.
+func (s *TaskService) GetSeason(ctx context.Context, userID string) (*entities.Season, *entities.SeasonProgress, error) {
+	s.log.Debug("usecase: get season", zap.String("user_id", userID))
+	season, err := s.seasons.GetActive(ctx, s.now())
+	if err != nil {
+		s.log.Warn("usecase: get season failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	progress, err := s.seasonProgress(ctx, s.seasons, userID, season.ID())
+	if err != nil {
+		s.log.Warn("usecase: get season failed", zap.Error(err))
+		return nil, nil, err
+	}
+	s.log.Debug("usecase: get season done", zap.String("season_id", season.ID()), zap.Int("xp", progress.XP()))
+	return season, progress, nil
+}
+
+// ClaimSeasonReward claims the reward of a reached season level on one track
+// and grants it in the same transaction. The returned grant is nil when the
+// level has no reward on the track. Rewards stay claimable after the season
+// ends.
+func (s *TaskService) ClaimSeasonReward(ctx context.Context, userID string, seasonID string, level int, track entities.SeasonTrack) (*entities.Season, *entities.SeasonProgress, *entities.RewardGrant, error) {
+	s.log.Info("usecase: claim season reward", zap.String("user_id", userID), zap.String("season_id", seasonID), zap.Int("level", level), zap.String("track", string(track)))
+	if !track.IsValid() {
+		s.log.Warn("usecase: claim season reward validation failed", zap.Error(exceptions.ErrSeasonTrackInvalid))
+		return nil, nil, nil, exceptions.ErrSeasonTrackInvalid
+	}
+
+	var (
+		season   *entities.Season
+		progress *entities.SeasonProgress
+		grant    *entities.RewardGrant
+	)
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		var err error
+		season, err = repos.Seasons.GetByID(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+		selected, err := season.Level(level)
+		if err != nil {
+			return err
+		}
+		if err := repos.Seasons.ClaimLevel(ctx, userID, seasonID, level, track, selected.XP); err != nil {
+			return err
+		}
+		if reward := selected.Reward(track); len(reward) > 0 {
+			grant = entities.NewSeasonRewardGrant(userID, seasonID, level, track, reward)
+			if err := s.enqueueGrant(ctx, repos, grant); err != nil {
+				return err
+			}
+		}
+
+		progress, err = repos.Seasons.GetProgress(ctx, userID, seasonID)
+		return err
+	})
+	if err != nil {
+		s.log.Warn("usecase: claim season reward failed", zap.Error(err))
+		return nil, nil, nil, err
+	}
+	s.log.Info("usecase: claim season reward done", zap.String("user_id", userID), zap.String("season_id", seasonID), zap.Int("level", level))
+	return season, progress, grant, nil
+}
+
+func (s *TaskService) CreateSeason(ctx context.Context, season *entities.Season) (*entities.Season, error) {
+	s.log.Info("usecase: create season", zap.String("name", season.Name()))
+	if err := season.Validate(); err != nil {
+		s.log.Warn("usecase: create season validation failed", zap.Error(err))
+		return nil, err
+	}
+	if err := s.checkLedgerRewards(seasonRewards(season)...); err != nil {
+		s.log.Warn("usecase: create season validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		overlaps, err := repos.Seasons.Overlaps(ctx, season.StartsAt(), season.EndsAt())
+		if err != nil {
+			return err
+		}
+		if overlaps {
+			return exceptions.ErrSeasonOverlap
+		}
+		return repos.Seasons.Create(ctx, season)
+	})
+	if err != nil {
+		s.log.Warn("usecase: create season failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: create season done", zap.String("season_id", season.ID()))
+	return season, nil
+}
+
+func (s *TaskService) SetSeasonPremium(ctx context.Context, userID string, seasonID string, premium bool) (*entities.Season, *entities.SeasonProgress, error) {
+	s.log.Info("usecase: set season premium", zap.String("user_id", userID), zap.String("season_id", seasonID), zap.Bool("premium", premium))
+	var (
+		season   *entities.Season
+		progress *entities.SeasonProgress
+	)
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		var err error
+		season, err = repos.Seasons.GetByID(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+		if err := repos.Seasons.SetPremium(ctx, userID, seasonID, premium, s.now()); err != nil {
+			return err
+		}
+
+		progress, err = repos.Seasons.GetProgress(ctx, userID, seasonID)
+		return err
+	})
+	if err != nil {
+		s.log.Warn("usecase: set season premium failed", zap.Error(err))
+		return nil, nil, err
+	}
+	s.log.Info("usecase: set season premium done", zap.String("user_id", userID), zap.String("season_id", seasonID))
+	return season, progress, nil
+}
+
+// grantSeasonXP credits the task's season XP to the season running now. Claims
+// made while no season runs grant nothing.
+func (s *TaskService) grantSeasonXP(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task) error {
+	if task.SeasonXP() == 0 {
+		return nil
+	}
+
+	now := s.now()
+	season, err := repos.Seasons.GetActive(ctx, now)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrSeasonNotFound) {
+			return nil
+		}
+		return err
+	}
+	s.log.Debug("usecase: grant season xp", zap.String("user_id", userID), zap.String("season_id", season.ID()), zap.Int("xp", task.SeasonXP()))
+	return repos.Seasons.AddXP(ctx, userID, season.ID(), task.SeasonXP(), now)
+}
+
+func (s *TaskService) seasonProgress(ctx context.Context, seasons ports.SeasonRepository, userID string, seasonID string) (*entities.SeasonProgress, error) {
+	progress, err := seasons.GetProgress(ctx, userID, seasonID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrProgressNotFound) {
+			return entities.NewSeasonProgress(userID, seasonID), nil
+		}
+		return nil, err
+	}
+	return progress, nil
+}
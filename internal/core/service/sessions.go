@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"task-manager/internal/core/domain/entities"
+	"task-manager/internal/core/ports"
+
+	"go.uber.org/zap"
+)
+
+// sessionExpiryBatchSize is how many expired sessions one transaction closes.
+const sessionExpiryBatchSize = 100
+
+// RunSessionExpiry closes expired sessions every interval until ctx is done.
+func (s *TaskService) RunSessionExpiry(ctx context.Context, interval time.Duration) {
+	s.log.Info("usecase: session expiry started", zap.Duration("interval", interval))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("usecase: session expiry stopped")
+			return
+		case <-ticker.C:
+			s.expireDue(ctx)
+		}
+	}
+}
+
+// expireDue closes the expired sessions batch by batch.
+func (s *TaskService) expireDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		expired, err := s.ExpireSessions(ctx)
+		if err != nil || expired < sessionExpiryBatchSize {
+			return
+		}
+	}
+}
+
+// ExpireSessions closes one batch of sessions open for longer than the max
+// session length, whose stop will never arrive, and credits each as abandoned
+// at the end of that length. It returns how many sessions it closed.
+func (s *TaskService) ExpireSessions(ctx context.Context) (int, error) {
+	var expired int
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		sessions, err := repos.Sessions.StopStartedBefore(ctx, s.now().Add(-s.maxSession), sessionExpiryBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, session := range sessions {
+			if err := s.creditAbandonedSession(ctx, repos, expiredSessionEventID(session), "", session, session.StartedAt.Add(s.maxSession)); err != nil {
+				return err
+			}
+		}
+		expired = len(sessions)
+		return nil
+	})
+	if err != nil {
+		s.log.Warn("usecase: expire sessions failed", zap.Error(err))
+		return 0, err
+	}
+	if expired > 0 {
+		s.log.Info("usecase: expire sessions done", zap.Int("count", expired))
+	}
+	return expired, nil
+}
+
+// expiredSessionEventID names the stop credited for an expired session, the
+// same for every attempt to expire it.
+func expiredSessionEventID(session *entities.Session) string {
+	return fmt.Sprintf("session_expired:%s:%s:%d", session.UserID, session.Key, session.StartedAt.UnixNano())
+}
@@ -13,40 +13,93 @@ import (
 )
 
 type TaskService struct {
-	tasks    ports.TaskRepository
-	progress ports.ProgressRepository
-	events   ports.EventRepository
-	uow      ports.UnitOfWorkManager
-	now      func() time.Time
-	log      *zap.Logger
+	tasks        ports.TaskRepository
+	progress     ports.ProgressRepository
+	events       ports.EventRepository
+	users        ports.UserRepository
+	seasons      ports.SeasonRepository
+	rooms        ports.RoomRepository
+	groups       ports.GroupRepository
+	leaderboards ports.LeaderboardRepository
+	achievements ports.AchievementRepository
+	ledger       ports.LedgerRepository
+	// ledgerRewards credits rewards to the built-in ledger instead of leaving
+	// them to the reward sink.
+	ledgerRewards bool
+	uow           ports.UnitOfWorkManager
+	location      *time.Location
+	maxSession    time.Duration
+	// maxLateness is how long after it happened an event still counts towards
+	// window and sequence tasks; older marks are pruned against it.
+	maxLateness time.Duration
+	now         func() time.Time
+	log         *zap.Logger
 }
 
 func NewTaskService(
 	tasks ports.TaskRepository,
 	progress ports.ProgressRepository,
 	events ports.EventRepository,
+	users ports.UserRepository,
+	seasons ports.SeasonRepository,
+	rooms ports.RoomRepository,
+	groups ports.GroupRepository,
+	leaderboards ports.LeaderboardRepository,
+	achievements ports.AchievementRepository,
+	ledger ports.LedgerRepository,
+	ledgerRewards bool,
 	uow ports.UnitOfWorkManager,
+	location *time.Location,
+	maxSession time.Duration,
+	maxLateness time.Duration,
 	log *zap.Logger,
 ) (*TaskService, error) {
 	if uow == nil {
 		return nil, errors.New("unit of work manager is nil")
 	}
+	if location == nil {
+		return nil, errors.New("period location is nil")
+	}
+	if maxSession <= 0 {
+		return nil, errors.New("max session length must be positive")
+	}
+	if maxLateness <= 0 {
+		return nil, errors.New("max event lateness must be positive")
+	}
 	if log == nil {
 		return nil, errors.New("logger is nil")
 	}
 	return &TaskService{
-		tasks:    tasks,
-		progress: progress,
-		events:   events,
-		uow:      uow,
-		now:      time.Now,
-		log:      log,
+		tasks:         tasks,
+		progress:      progress,
+		events:        events,
+		users:         users,
+		seasons:       seasons,
+		rooms:         rooms,
+		groups:        groups,
+		leaderboards:  leaderboards,
+		achievements:  achievements,
+		ledger:        ledger,
+		ledgerRewards: ledgerRewards,
+		uow:           uow,
+		location:      location,
+		maxSession:    maxSession,
+		maxLateness:   maxLateness,
+		now:           time.Now,
+		log:           log,
 	}, nil
 }
 
 func (s *TaskService) GetTasksWithProgress(ctx context.Context, userID string) ([]*entities.Task, []*entities.TaskProgress, error) {
 	s.log.Debug("usecase: get tasks with progress", zap.String("user_id", userID))
-	tasks, err := s.tasks.ListActive(ctx)
+	now := s.now()
+	tasks, err := s.tasks.ListActive(ctx, now)
+	if err != nil {
+		s.log.Warn("usecase: get tasks with progress failed", zap.Error(err))
+		return nil, nil, err
+	}
+
+	loc, err := s.userLocation(ctx, s.users, userID)
 	if err != nil {
 		s.log.Warn("usecase: get tasks with progress failed", zap.Error(err))
 		return nil, nil, err
@@ -54,13 +107,41 @@ func (s *TaskService) GetTasksWithProgress(ctx context.Context, userID string) (
 
 	progressList := make([]*entities.TaskProgress, 0, len(tasks))
 	for _, task := range tasks {
-		progress, err := s.progress.Get(ctx, userID, task.ID())
+		taskLoc := loc
+		if task.IsShared() {
+			taskLoc = s.location
+		}
+		periodKey := entities.PeriodKey(task.ResetPeriod(), now, taskLoc)
+		progress, err := s.userProgress(ctx, userID, task, periodKey)
 		if err != nil {
 			if !errors.Is(err, exceptions.ErrProgressNotFound) {
 				s.log.Warn("usecase: get tasks with progress failed", zap.Error(err))
 				return nil, nil, err
 			}
-			progress = entities.NewTaskProgress(task.ID(), userID)
+			progress = entities.NewTaskProgress(task.ID(), userID, periodKey)
+		}
+		progress.SetPeriodEndsAt(entities.PeriodEnd(task.ResetPeriod(), now, taskLoc))
+		if !task.IsRepeatable() {
+			progress.ResolveTiers(task.Tiers())
+		}
+
+		unlocked, err := s.isUnlocked(ctx, s.progress, userID, task)
+		if err != nil {
+			s.log.Warn("usecase: get tasks with progress failed", zap.Error(err))
+			return nil, nil, err
+		}
+		progress.SetLocked(!unlocked)
+
+		if config := task.Streak(); config != nil {
+			streak, err := s.progress.GetStreak(ctx, userID, task.ID())
+			if err != nil && !errors.Is(err, exceptions.ErrProgressNotFound) {
+				s.log.Warn("usecase: get tasks with progress failed", zap.Error(err))
+				return nil, nil, err
+			}
+			if streak != nil {
+				today := entities.PeriodKey(entities.ResetPeriodDaily, now, loc)
+				progress.SetStreak(streak.CurrentAt(today, config.GraceDays), streak.Longest())
+			}
 		}
 		progressList = append(progressList, progress)
 	}
@@ -69,6 +150,23 @@ func (s *TaskService) GetTasksWithProgress(ctx context.Context, userID string) (
 	return tasks, progressList, nil
 }
 
+// userProgress returns the user's own progress, or for room and group tasks
+// the progress of the room or group the user contributed to.
+func (s *TaskService) userProgress(ctx context.Context, userID string, task *entities.Task, periodKey string) (*entities.TaskProgress, error) {
+	if !task.IsShared() {
+		return s.progress.Get(ctx, userID, task.ID(), periodKey)
+	}
+	roomID, err := s.rooms.FindUserRoom(ctx, userID, task.ID(), periodKey)
+	if err != nil {
+		return nil, err
+	}
+	room, err := s.rooms.Get(ctx, task.ID(), roomID, periodKey)
+	if err != nil {
+		return nil, err
+	}
+	return room.UserProgress(userID), nil
+}
+
 func (s *TaskService) GetTask(ctx context.Context, taskID string) (*entities.Task, error) {
 	s.log.Debug("usecase: get task", zap.String("task_id", taskID))
 	task, err := s.tasks.GetByID(ctx, taskID)
@@ -142,30 +240,228 @@ func (s *TaskService) ProcessEvents(ctx context.Context, events []*entities.Task
 	return accepted, rejected, nil
 }
 
-func (s *TaskService) ClaimReward(ctx context.Context, userID string, taskID string) error {
-	s.log.Info("usecase: claim reward", zap.String("user_id", userID), zap.String("task_id", taskID))
+// ClaimReward claims a task reward and returns the claim with the updated
+// progress. Claiming a reward that was already claimed fails with
+// ErrRewardAlreadyClaimed, unless the request repeats the idempotency key of
+// the original claim, in which case the original claim is returned.
+func (s *TaskService) ClaimReward(ctx context.Context, userID string, taskID string, tier int, idempotencyKey string) (*entities.RewardClaim, *entities.TaskProgress, error) {
+	s.log.Info("usecase: claim reward", zap.String("user_id", userID), zap.String("task_id", taskID), zap.Int("tier", tier), zap.String("idempotency_key", idempotencyKey))
+	var (
+		claim    *entities.RewardClaim
+		progress *entities.TaskProgress
+	)
 	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
 		repos := uow.Repositories()
 
-		if _, err := repos.Tasks.GetByID(ctx, taskID); err != nil {
+		var err error
+		if idempotencyKey != "" {
+			claim, progress, err = s.replayClaim(ctx, repos, userID, taskID, idempotencyKey)
+			if err != nil || claim != nil {
+				return err
+			}
+		}
+
+		task, err := repos.Tasks.GetByID(ctx, taskID)
+		if err != nil {
 			return err
 		}
 
-		if err := repos.Progress.Claim(ctx, userID, taskID); err != nil {
-			if errors.Is(err, exceptions.ErrRewardAlreadyClaimed) {
-				return nil
-			}
+		loc, err := s.userLocation(ctx, repos.Users, userID)
+		if err != nil {
 			return err
 		}
 
-		return nil
+		claim, err = s.claimTask(ctx, repos, userID, task, tier, loc)
+		if err != nil {
+			return err
+		}
+		claim.IdempotencyKey = idempotencyKey
+		if err := s.applyClaim(ctx, repos, task, claim); err != nil {
+			return err
+		}
+		if idempotencyKey != "" {
+			if err := repos.RewardClaims.Save(ctx, claim); err != nil {
+				return err
+			}
+		}
+
+		progress, err = s.claimedProgress(ctx, repos, task, claim, loc)
+		return err
 	})
+	if idempotencyKey != "" && (errors.Is(err, exceptions.ErrRewardAlreadyClaimed) || errors.Is(err, exceptions.ErrIdempotencyKeyExists)) {
+		// A concurrent request with the same key may have claimed the reward
+		// while this one waited for it. Repeatable tasks, and tiered tasks
+		// claimed without a tier, can be claimed by both requests; the second
+		// then finds the key taken when saving and its claim is rolled back.
+		err = s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+			var replayErr error
+			claim, progress, replayErr = s.replayClaim(ctx, uow.Repositories(), userID, taskID, idempotencyKey)
+			if replayErr == nil && claim == nil {
+				return exceptions.ErrRewardAlreadyClaimed
+			}
+			return replayErr
+		})
+	}
 	if err != nil {
 		s.log.Warn("usecase: claim reward failed", zap.Error(err))
+		return nil, nil, err
+	}
+	s.log.Info("usecase: claim reward done", zap.String("user_id", userID), zap.String("task_id", taskID), zap.Int("tier", claim.Tier), zap.Bool("replayed", claim.Replayed))
+	return claim, progress, nil
+}
+
+// applyClaim grants what a claim earns: the reward, the season XP of the task
+// and the claim counters.
+func (s *TaskService) applyClaim(ctx context.Context, repos ports.Repositories, task *entities.Task, claim *entities.RewardClaim) error {
+	if err := s.grantReward(ctx, repos, claim); err != nil {
 		return err
 	}
-	s.log.Info("usecase: claim reward done", zap.String("user_id", userID), zap.String("task_id", taskID))
-	return nil
+	if err := s.grantSeasonXP(ctx, repos, claim.UserID, task); err != nil {
+		return err
+	}
+	return s.bumpCounters(ctx, repos, claim.UserID,
+		entities.CounterTasksClaimed,
+		entities.QualifiedCounter(entities.CounterTasksClaimed, string(task.Type())),
+	)
+}
+
+// replayClaim returns the claim the user made with the idempotency key and the
+// current progress of its task, or nil when the key was not used yet.
+func (s *TaskService) replayClaim(ctx context.Context, repos ports.Repositories, userID string, taskID string, idempotencyKey string) (*entities.RewardClaim, *entities.TaskProgress, error) {
+	claim, err := repos.RewardClaims.Get(ctx, userID, idempotencyKey)
+	if err != nil || claim == nil {
+		return nil, nil, err
+	}
+	if claim.TaskID != taskID {
+		return nil, nil, exceptions.ErrIdempotencyKeyReused
+	}
+
+	task, err := repos.Tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	loc, err := s.userLocation(ctx, repos.Users, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	progress, err := s.claimedProgress(ctx, repos, task, claim, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+	claim.Replayed = true
+	return claim, progress, nil
+}
+
+// claimedProgress returns the user's progress in the period of the claim.
+func (s *TaskService) claimedProgress(ctx context.Context, repos ports.Repositories, task *entities.Task, claim *entities.RewardClaim, loc *time.Location) (*entities.TaskProgress, error) {
+	var progress *entities.TaskProgress
+	if task.IsShared() {
+		loc = s.location
+		room, err := repos.Rooms.Get(ctx, task.ID(), claim.RoomID, claim.PeriodKey)
+		if err != nil {
+			return nil, err
+		}
+		progress = room.UserProgress(claim.UserID)
+	} else {
+		var err error
+		progress, err = repos.Progress.Get(ctx, claim.UserID, task.ID(), claim.PeriodKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	progress.SetPeriodEndsAt(entities.PeriodEnd(task.ResetPeriod(), claim.ClaimedAt, loc))
+	if !task.IsRepeatable() {
+		progress.ResolveTiers(task.Tiers())
+	}
+	return progress, nil
+}
+
+// claimTask claims the task reward in the current period. It fails with
+// ErrRewardAlreadyClaimed when there is nothing left to claim.
+func (s *TaskService) claimTask(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task, tier int, loc *time.Location) (*entities.RewardClaim, error) {
+	now := s.now()
+	periodKey := entities.PeriodKey(task.ResetPeriod(), now, loc)
+	claim := &entities.RewardClaim{
+		UserID:    userID,
+		TaskID:    task.ID(),
+		PeriodKey: periodKey,
+		Reward:    task.Reward(),
+		ClaimedAt: now,
+	}
+	if task.IsShared() {
+		claim.PeriodKey = entities.PeriodKey(task.ResetPeriod(), now, s.location)
+		roomID, err := s.claimSharedTask(ctx, repos, userID, task, claim.PeriodKey)
+		if err != nil {
+			return nil, err
+		}
+		claim.RoomID = roomID
+		return claim, nil
+	}
+	if task.IsRepeatable() {
+		if err := repos.Progress.ClaimRepetition(ctx, userID, task.ID(), periodKey, task.RepeatLimit()); err != nil {
+			return nil, err
+		}
+		return claim, nil
+	}
+
+	tiers := task.Tiers()
+	number := tier
+	if number == 0 {
+		progress, err := repos.Progress.Get(ctx, userID, task.ID(), periodKey)
+		if err != nil {
+			return nil, err
+		}
+		number, err = progress.NextClaimableTier(tiers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	selected, err := task.Tier(number)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repos.Progress.Claim(ctx, userID, task.ID(), periodKey, number, selected.Target, len(tiers)); err != nil {
+		return nil, err
+	}
+	claim.Tier = number
+	claim.Reward = selected.Reward
+	return claim, nil
+}
+
+// SetUserTimezone sets the timezone of a user without one, or schedules a
+// change of the current one; see UserSettings for when a change applies.
+func (s *TaskService) SetUserTimezone(ctx context.Context, userID string, timezone string) (*entities.UserSettings, error) {
+	s.log.Info("usecase: set user timezone", zap.String("user_id", userID), zap.String("timezone", timezone))
+	if _, err := entities.LoadTimezone(timezone); err != nil {
+		s.log.Warn("usecase: set user timezone validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	var settings *entities.UserSettings
+	err := s.uow.Do(ctx, func(uow ports.UnitOfWork) error {
+		repos := uow.Repositories()
+
+		now := s.now()
+		var err error
+		settings, err = repos.Users.GetSettings(ctx, userID)
+		if err != nil {
+			if !errors.Is(err, exceptions.ErrUserSettingsNotFound) {
+				return err
+			}
+			settings = entities.NewUserSettings(userID, timezone, now)
+		} else if err := settings.ChangeTimezone(timezone, now); err != nil {
+			return err
+		}
+		return repos.Users.SaveSettings(ctx, settings)
+	})
+	if err != nil {
+		s.log.Warn("usecase: set user timezone failed", zap.Error(err))
+		return nil, err
+	}
+	s.log.Info("usecase: set user timezone done", zap.String("user_id", userID), zap.String("pending_timezone", settings.PendingTimezone), zap.Time("pending_from", settings.PendingFrom))
+	return settings, nil
 }
 
 func (s *TaskService) processEventWithRepos(ctx context.Context, repos ports.Repositories, event *entities.TaskEvent) error {
@@ -178,17 +474,37 @@ func (s *TaskService) processEventWithRepos(ctx context.Context, repos ports.Rep
 		return nil
 	}
 
+	// Events only set the timezone of users without one; changes go through
+	// SetUserTimezone.
+	if event.Timezone() != "" {
+		if err := repos.Users.CreateSettings(ctx, entities.NewUserSettings(event.UserID(), event.Timezone(), s.now())); err != nil {
+			return err
+		}
+	}
+
+	progress := event
 	switch event.Type() {
-	case entities.EventTypeProgressUpdate,
-		entities.EventTypeTaskSubscribed,
-		entities.EventTypeTaskStepCounted:
-		// TODO: Обсудить, как дальше обрабатывать все типы событий.
-		payload := event.Payload()
-		if err := s.applyProgressUpdate(ctx, repos, event.UserID(), payload.TaskID, payload.Amount); err != nil {
+	case entities.EventTypeSessionStarted:
+		if err := s.startSession(ctx, repos, event); err != nil {
+			return err
+		}
+	case entities.EventTypeSessionStopped:
+		if progress, err = s.stopSession(ctx, repos, event); err != nil {
+			return err
+		}
+	}
+
+	if progress != nil && progress.Amount() > 0 {
+		if err := s.applyEvent(ctx, repos, progress); err != nil {
 			return err
 		}
-	default:
-		return exceptions.ErrUnsupportedEventType
+	}
+
+	if err := s.bumpCounters(ctx, repos, event.UserID(),
+		entities.CounterEventsProcessed,
+		entities.QualifiedCounter(entities.CounterEventsProcessed, string(event.Type())),
+	); err != nil {
+		return err
 	}
 
 	if event.ProcessedAt().IsZero() {
@@ -197,21 +513,433 @@ func (s *TaskService) processEventWithRepos(ctx context.Context, repos ports.Rep
 	return repos.Events.MarkProcessed(ctx, event)
 }
 
+// applyEvent advances every task matched by the event.
+func (s *TaskService) applyEvent(ctx context.Context, repos ports.Repositories, event *entities.TaskEvent) error {
+	tasks, err := s.matchTasks(ctx, repos, event)
+	if err != nil {
+		return err
+	}
+
+	explicit := event.TaskID() != ""
+	for _, task := range tasks {
+		if err := s.applyProgressUpdate(ctx, repos, task, event); err != nil {
+			if !explicit && isNonFatalEventError(err) {
+				s.log.Debug("usecase: matched task skipped", zap.String("event_id", event.EventID()), zap.String("task_id", task.ID()), zap.Error(err))
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// startSession opens a session for the user. A session still open under the
+// same key never received its stop; it is closed at this start and credited
+// for at most the max session length.
+func (s *TaskService) startSession(ctx context.Context, repos ports.Repositories, event *entities.TaskEvent) error {
+	startedAt := s.eventTime(event)
+	key := entities.SessionKey(event)
+
+	previous, err := repos.Sessions.Stop(ctx, event.UserID(), key)
+	switch {
+	case err == nil:
+		if err := s.creditAbandonedSession(ctx, repos, event.EventID(), event.RoomID(), previous, startedAt); err != nil {
+			return err
+		}
+	case !errors.Is(err, exceptions.ErrSessionNotFound):
+		return err
+	}
+
+	return repos.Sessions.Start(ctx, &entities.Session{
+		UserID:     event.UserID(),
+		Key:        key,
+		StartedAt:  startedAt,
+		Attributes: event.RawAttributes(),
+	})
+}
+
+// stopSession closes the user's session and returns the event that advances
+// tasks by the minutes it lasted. That event takes the attributes the session
+// started with, so tasks match a session the same way whether it was stopped
+// or abandoned; the stop event itself is stored as sent. A stop without a
+// matching start credits nothing and returns nil.
+func (s *TaskService) stopSession(ctx context.Context, repos ports.Repositories, event *entities.TaskEvent) (*entities.TaskEvent, error) {
+	session, err := repos.Sessions.Stop(ctx, event.UserID(), entities.SessionKey(event))
+	if err != nil {
+		if errors.Is(err, exceptions.ErrSessionNotFound) {
+			s.log.Debug("usecase: session stop without start", zap.String("event_id", event.EventID()))
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sessionStop(event.EventID(), event.RoomID(), event.TaskID(), session, s.eventTime(event), s.maxSession)
+}
+
+// creditAbandonedSession applies an abandoned session as if its stop event had
+// arrived at stoppedAt, matching tasks against the attributes it started with.
+func (s *TaskService) creditAbandonedSession(ctx context.Context, repos ports.Repositories, eventID, roomID string, session *entities.Session, stoppedAt time.Time) error {
+	stop, err := sessionStop(eventID, roomID, "", session, stoppedAt, s.maxSession)
+	if err != nil || stop == nil {
+		return err
+	}
+	s.log.Debug("usecase: crediting abandoned session", zap.String("user_id", session.UserID), zap.Int("minutes", stop.Amount()))
+	return s.applyEvent(ctx, repos, stop)
+}
+
+// sessionStop builds the stop event that credits the session's minutes, or
+// returns nil when the session lasted less than a minute.
+func sessionStop(eventID, roomID, taskID string, session *entities.Session, stoppedAt time.Time, maxSession time.Duration) (*entities.TaskEvent, error) {
+	minutes := entities.SessionMinutes(session.StartedAt, stoppedAt, maxSession)
+	if minutes == 0 {
+		return nil, nil
+	}
+	stop, err := entities.NewTaskEvent(
+		eventID,
+		session.UserID,
+		roomID,
+		entities.EventTypeSessionStopped,
+		&entities.ProgressPayload{TaskID: taskID, Amount: minutes},
+		stoppedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	stop.SetAttributes(session.Attributes)
+	return stop, nil
+}
+
+// eventTime is when the event happened according to the sender, or now when
+// the sender did not say.
+func (s *TaskService) eventTime(event *entities.TaskEvent) time.Time {
+	if event.CreatedAt().IsZero() {
+		return s.now()
+	}
+	return event.CreatedAt()
+}
+
+// matchTasks resolves the tasks advanced by the event: the task named in the
+// payload, or every active task whose rule matches the event.
+func (s *TaskService) matchTasks(ctx context.Context, repos ports.Repositories, event *entities.TaskEvent) ([]*entities.Task, error) {
+	if taskID := event.TaskID(); taskID != "" {
+		task, err := repos.Tasks.GetByID(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if !task.Rule().ConditionHolds(event) {
+			return nil, exceptions.ErrEventConditionNotMet
+		}
+		return []*entities.Task{task}, nil
+	}
+
+	candidates, err := repos.Tasks.ListActiveByEventType(ctx, event.Type(), s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]*entities.Task, 0, len(candidates))
+	for _, task := range candidates {
+		if task.Rule().Matches(event) {
+			matched = append(matched, task)
+		}
+	}
+	s.log.Debug("usecase: event matched tasks", zap.String("event_id", event.EventID()), zap.Int("tasks", len(matched)))
+	return matched, nil
+}
+
 func isNonFatalEventError(err error) bool {
-	return errors.Is(err, exceptions.ErrUnsupportedEventType) ||
-		errors.Is(err, exceptions.ErrTaskNotFound) ||
-		errors.Is(err, exceptions.ErrTaskInactive)
+	return errors.Is(err, exceptions.ErrTaskNotFound) ||
+		errors.Is(err, exceptions.ErrTaskInactive) ||
+		errors.Is(err, exceptions.ErrTaskNotAvailable) ||
+		errors.Is(err, exceptions.ErrTaskLocked) ||
+		errors.Is(err, exceptions.ErrEventConditionNotMet) ||
+		errors.Is(err, exceptions.ErrEventAttributeMissing) ||
+		errors.Is(err, exceptions.ErrEventValueOutOfRange) ||
+		errors.Is(err, exceptions.ErrEventTooLate) ||
+		errors.Is(err, exceptions.ErrQuestProgressDerived) ||
+		errors.Is(err, exceptions.ErrStreakProgressDerived) ||
+		errors.Is(err, exceptions.ErrEventRoomRequired) ||
+		errors.Is(err, exceptions.ErrGroupMembershipRequired)
 }
 
-func (s *TaskService) applyProgressUpdate(ctx context.Context, repos ports.Repositories, userID string, taskID string, amount int) error {
-	task, err := repos.Tasks.GetByID(ctx, taskID)
+func (s *TaskService) applyProgressUpdate(ctx context.Context, repos ports.Repositories, task *entities.Task, event *entities.TaskEvent) error {
+	userID := event.UserID()
+	if task.IsQuest() {
+		return exceptions.ErrQuestProgressDerived
+	}
+	if task.IsStreak() {
+		return exceptions.ErrStreakProgressDerived
+	}
+
+	now := s.now()
+	if err := s.checkProgressable(ctx, repos, userID, task, now); err != nil {
+		return err
+	}
+	if task.IsShared() {
+		return s.addSharedProgress(ctx, repos, task, event)
+	}
+
+	loc, err := s.userLocation(ctx, repos.Users, userID)
 	if err != nil {
 		return err
 	}
 
+	periodKey := entities.PeriodKey(task.ResetPeriod(), now, loc)
+	if err := s.addEventProgress(ctx, repos, task, event, periodKey); err != nil {
+		return err
+	}
+	return s.propagateCompletion(ctx, repos, userID, task, periodKey, loc)
+}
+
+// checkProgressable rejects progress on tasks the user cannot advance right now.
+func (s *TaskService) checkProgressable(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task, now time.Time) error {
 	if !task.IsActive() {
 		return exceptions.ErrTaskInactive
 	}
+	if !task.IsAvailableAt(now) {
+		return exceptions.ErrTaskNotAvailable
+	}
+
+	unlocked, err := s.isUnlocked(ctx, repos.Progress, userID, task)
+	if err != nil {
+		return err
+	}
+	if !unlocked {
+		return exceptions.ErrTaskLocked
+	}
+	return nil
+}
+
+func (s *TaskService) addEventProgress(ctx context.Context, repos ports.Repositories, task *entities.Task, event *entities.TaskEvent, periodKey string) error {
+	userID := event.UserID()
+	now := s.now()
+	aggregation := task.Aggregation()
+	if aggregation.IsTimed() {
+		return s.applyTimedProgress(ctx, repos, task, event, periodKey)
+	}
+	if aggregation.Mode == entities.AggregationDistinct {
+		value, err := aggregation.DistinctValue(event)
+		if err != nil {
+			return err
+		}
+		return repos.Progress.AddDistinct(ctx, userID, task.ID(), periodKey, value, task.Target(), now)
+	}
+
+	value, err := aggregation.NumericValue(event)
+	if err != nil {
+		return err
+	}
+	if err := s.recordLeaderboard(ctx, repos, task, userID, value); err != nil {
+		return err
+	}
+	if aggregation.Mode == entities.AggregationSum {
+		return repos.Progress.AddProgress(ctx, userID, task.ID(), periodKey, value, task.Target(), task.RepeatLimit(), now)
+	}
+	return repos.Progress.SetProgress(ctx, userID, task.ID(), periodKey, value, task.Target(), aggregation.Mode, now)
+}
+
+// propagateCompletion records a task the user has completed in the period for
+// achievements and feeds it into the quests and streaks built on it.
+func (s *TaskService) propagateCompletion(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task, periodKey string, loc *time.Location) error {
+	progress, err := repos.Progress.Get(ctx, userID, task.ID(), periodKey)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrProgressNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !progress.Completed() {
+		return nil
+	}
+
+	if err := s.recordCompletion(ctx, repos, userID, task); err != nil {
+		return err
+	}
+	if err := s.advanceQuests(ctx, repos, userID, task, loc); err != nil {
+		return err
+	}
+	return s.advanceStreaks(ctx, repos, userID, task, loc)
+}
+
+// advanceQuests counts a completed task towards every quest it belongs to and
+// cascades into quests of quests. Each child counts once per quest period, so
+// repeated completions and redelivered events do not inflate the quest.
+func (s *TaskService) advanceQuests(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task, loc *time.Location) error {
+	now := s.now()
+	for _, questID := range task.QuestIDs() {
+		quest, err := repos.Tasks.GetByID(ctx, questID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkProgressable(ctx, repos, userID, quest, now); err != nil {
+			if isNonFatalEventError(err) {
+				s.log.Debug("usecase: quest skipped", zap.String("quest_id", questID), zap.String("task_id", task.ID()), zap.Error(err))
+				continue
+			}
+			return err
+		}
+
+		questKey := entities.PeriodKey(quest.ResetPeriod(), now, loc)
+		if err := repos.Progress.AddDistinct(ctx, userID, questID, questKey, task.ID(), quest.Target(), now); err != nil {
+			return err
+		}
+		if err := s.propagateCompletion(ctx, repos, userID, quest, questKey, loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advanceStreaks extends every streak tracking a completed task by the user's
+// current day. Streak progress is the longest streak, so milestone tiers stay
+// claimable after the streak breaks.
+func (s *TaskService) advanceStreaks(ctx context.Context, repos ports.Repositories, userID string, task *entities.Task, loc *time.Location) error {
+	now := s.now()
+	day := entities.PeriodKey(entities.ResetPeriodDaily, now, loc)
+	for _, streakID := range task.StreakIDs() {
+		streakTask, err := repos.Tasks.GetByID(ctx, streakID)
+		if err != nil {
+			return err
+		}
+		config := streakTask.Streak()
+		if config == nil {
+			continue
+		}
+		if err := s.checkProgressable(ctx, repos, userID, streakTask, now); err != nil {
+			if isNonFatalEventError(err) {
+				s.log.Debug("usecase: streak skipped", zap.String("streak_id", streakID), zap.String("task_id", task.ID()), zap.Error(err))
+				continue
+			}
+			return err
+		}
+
+		streak, err := repos.Progress.GetStreak(ctx, userID, streakID)
+		if err != nil {
+			if !errors.Is(err, exceptions.ErrProgressNotFound) {
+				return err
+			}
+			streak = entities.NewStreak(userID, streakID)
+		}
+		if !streak.Record(day, config.GraceDays, now) {
+			continue
+		}
+		if err := repos.Progress.SaveStreak(ctx, streak); err != nil {
+			return err
+		}
+
+		periodKey := entities.PeriodKey(streakTask.ResetPeriod(), now, loc)
+		if err := repos.Progress.SetProgress(ctx, userID, streakID, periodKey, streak.Longest(), streakTask.Target(), entities.AggregationMax, now); err != nil {
+			return err
+		}
+		if err := s.propagateCompletion(ctx, repos, userID, streakTask, periodKey, loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyTimedProgress records the steps the event satisfies at the time it
+// happened and recomputes the best window count or sequence run, so events
+// delivered out of order are still evaluated against each other correctly.
+// Events more than maxLateness old are rejected, since the marks they could
+// pair with may have been pruned.
+func (s *TaskService) applyTimedProgress(ctx context.Context, repos ports.Repositories, task *entities.Task, event *entities.TaskEvent, periodKey string) error {
+	aggregation := task.Aggregation()
+	steps := aggregation.MatchedSteps(event)
+	if len(steps) == 0 {
+		return exceptions.ErrEventConditionNotMet
+	}
+
+	userID := event.UserID()
+	at := s.eventTime(event)
+	if at.Before(s.now().Add(-s.maxLateness)) {
+		return exceptions.ErrEventTooLate
+	}
+	for _, step := range steps {
+		mark := entities.ProgressMark{Step: step, EventID: event.EventID(), OccurredAt: at}
+		if err := repos.Progress.AddMark(ctx, userID, task.ID(), periodKey, mark); err != nil {
+			return err
+		}
+	}
+
+	// Only runs that include the new mark can beat the stored progress, and
+	// with a window those lie within one window of it.
+	var from, to time.Time
+	if aggregation.Window > 0 {
+		from, to = at.Add(-aggregation.Window), at.Add(aggregation.Window)
+	}
+	marks, err := repos.Progress.ListMarks(ctx, userID, task.ID(), periodKey, from, to)
+	if err != nil {
+		return err
+	}
+
+	var value int
+	if aggregation.Mode == entities.AggregationWindow {
+		value = entities.WindowCount(marks, aggregation.Window)
+	} else {
+		value = entities.SequenceProgress(marks, len(aggregation.Steps), aggregation.Window)
+	}
+
+	if err := repos.Progress.SetProgress(ctx, userID, task.ID(), periodKey, value, task.Target(), entities.AggregationMax, s.now()); err != nil {
+		return err
+	}
+	if value >= task.Target() {
+		return repos.Progress.ClearMarks(ctx, userID, task.ID(), periodKey)
+	}
+	return s.pruneMarks(ctx, repos, task, userID, periodKey, marks)
+}
 
-	return repos.Progress.AddProgress(ctx, userID, task.ID(), amount, task.Target(), s.now())
+// pruneMarks drops the marks no accepted event can use any more, so marks do
+// not pile up while a task stays incomplete. Past periods get no new events.
+// Within the period only marks older than maxLateness are settled: with a
+// window, settled marks more than a window old cannot share one with a newer
+// event; without one, only the settled marks of the run from the earliest
+// first step can still be continued.
+func (s *TaskService) pruneMarks(ctx context.Context, repos ports.Repositories, task *entities.Task, userID string, periodKey string, marks []entities.ProgressMark) error {
+	if task.ResetPeriod() != entities.ResetPeriodNone {
+		if err := repos.Progress.DeletePastPeriodMarks(ctx, userID, task.ID(), periodKey); err != nil {
+			return err
+		}
+	}
+
+	settled := s.now().Add(-s.maxLateness)
+	aggregation := task.Aggregation()
+	if aggregation.Window > 0 {
+		return repos.Progress.DeleteMarksBefore(ctx, userID, task.ID(), periodKey, settled.Add(-aggregation.Window))
+	}
+	return repos.Progress.DeleteMarks(ctx, userID, task.ID(), periodKey, entities.SettledSequenceMarks(marks, len(aggregation.Steps), settled))
+}
+
+// isUnlocked reports whether the user has claimed every prerequisite of the task.
+func (s *TaskService) isUnlocked(ctx context.Context, progress ports.ProgressRepository, userID string, task *entities.Task) (bool, error) {
+	prerequisites := task.Prerequisites()
+	if len(prerequisites) == 0 {
+		return true, nil
+	}
+
+	claimed, err := progress.ClaimedTaskIDs(ctx, userID, prerequisites)
+	if err != nil {
+		return false, err
+	}
+	return len(claimed) == len(prerequisites), nil
+}
+
+// userLocation resolves the timezone used for the user's period boundaries,
+// falling back to the service default when none is stored.
+func (s *TaskService) userLocation(ctx context.Context, users ports.UserRepository, userID string) (*time.Location, error) {
+	settings, err := users.GetSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrUserSettingsNotFound) {
+			return s.location, nil
+		}
+		return nil, err
+	}
+
+	timezone := settings.TimezoneAt(s.now())
+
+	loc, err := entities.LoadTimezone(timezone)
+	if err != nil {
+		s.log.Warn("usecase: stored user timezone is invalid", zap.String("user_id", userID), zap.String("timezone", timezone))
+		return s.location, nil
+	}
+	return loc, nil
 }
@@ -1,11 +1,15 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"time"
 
 	grpcadapter "task-manager/internal/adapters/input/grpc"
+	"task-manager/internal/adapters/output/memory"
 	"task-manager/internal/adapters/output/postgres"
+	"task-manager/internal/adapters/output/webhook"
 	"task-manager/internal/config"
 	"task-manager/internal/core/ports"
 	"task-manager/internal/core/service"
@@ -20,11 +24,13 @@ import (
 )
 
 type App struct {
-	Config     *config.Config
-	Log        *zap.Logger
-	GRPCServer *grpc.Server
-	Listener   net.Listener
-	close      func()
+	Config           *config.Config
+	Log              *zap.Logger
+	GRPCServer       *grpc.Server
+	Listener         net.Listener
+	RewardDispatcher *service.RewardDispatcher
+	TaskService      *service.TaskService
+	close            func()
 }
 
 func Init() (*App, error) {
@@ -48,17 +54,51 @@ func Init() (*App, error) {
 	taskRepo := postgres.NewTaskRepository(pool, log)
 	progressRepo := postgres.NewProgressRepository(pool, log)
 	eventRepo := postgres.NewEventRepository(pool, log)
+	userRepo := postgres.NewUserRepository(pool, log)
+	seasonRepo := postgres.NewSeasonRepository(pool, log)
+	roomRepo := postgres.NewRoomRepository(pool, log)
+	groupRepo := postgres.NewGroupRepository(pool, log)
+	leaderboardRepo := postgres.NewLeaderboardRepository(pool, log)
+	achievementRepo := postgres.NewAchievementRepository(pool, log)
+	rewardOutboxRepo := postgres.NewRewardOutboxRepository(pool, log)
+	ledgerRepo := postgres.NewLedgerRepository(pool, log)
 
 	repoFactory := func(q dbinfra.Querier) ports.Repositories {
 		return ports.Repositories{
-			Tasks:    postgres.NewTaskRepository(q, log),
-			Progress: postgres.NewProgressRepository(q, log),
-			Events:   postgres.NewEventRepository(q, log),
+			Tasks:        postgres.NewTaskRepository(q, log),
+			Progress:     postgres.NewProgressRepository(q, log),
+			Events:       postgres.NewEventRepository(q, log),
+			Users:        postgres.NewUserRepository(q, log),
+			Sessions:     postgres.NewSessionRepository(q, log),
+			Seasons:      postgres.NewSeasonRepository(q, log),
+			Rooms:        postgres.NewRoomRepository(q, log),
+			Groups:       postgres.NewGroupRepository(q, log),
+			Leaderboards: postgres.NewLeaderboardRepository(q, log),
+			Achievements: postgres.NewAchievementRepository(q, log),
+			RewardOutbox: postgres.NewRewardOutboxRepository(q, log),
+			Ledger:       postgres.NewLedgerRepository(q, log),
+			RewardClaims: postgres.NewRewardClaimRepository(q, log),
 		}
 	}
 	uow := dbinfra.NewUnitOfWorkManager(pool, log, repoFactory)
 
-	taskService, err := service.NewTaskService(taskRepo, progressRepo, eventRepo, uow, log)
+	periodLocation, err := time.LoadLocation(cfg.Tasks.PeriodTimezone)
+	if err != nil {
+		log.Error("failed to load tasks period timezone", zap.String("timezone", cfg.Tasks.PeriodTimezone), zap.Error(err))
+		pool.Close()
+		_ = log.Sync()
+		return nil, err
+	}
+
+	if cfg.Tasks.SessionExpiryInterval <= 0 {
+		log.Error("invalid session expiry interval", zap.Duration("interval", cfg.Tasks.SessionExpiryInterval))
+		pool.Close()
+		_ = log.Sync()
+		return nil, fmt.Errorf("session expiry interval must be positive, got %s", cfg.Tasks.SessionExpiryInterval)
+	}
+
+	ledgerRewards := cfg.Rewards.Sink == "ledger"
+	taskService, err := service.NewTaskService(taskRepo, progressRepo, eventRepo, userRepo, seasonRepo, roomRepo, groupRepo, leaderboardRepo, achievementRepo, ledgerRepo, ledgerRewards, uow, periodLocation, cfg.Tasks.MaxSessionLength, cfg.Tasks.MaxEventLateness, log)
 	if err != nil {
 		log.Error("failed to init task service", zap.Error(err))
 		pool.Close()
@@ -66,6 +106,46 @@ func Init() (*App, error) {
 		return nil, err
 	}
 
+	if err := taskService.CheckLedgerRewards(context.Background()); err != nil {
+		log.Error("rewards cannot be delivered with the ledger sink", zap.Error(err))
+		pool.Close()
+		_ = log.Sync()
+		return nil, err
+	}
+
+	var rewardSink ports.RewardSink
+	switch cfg.Rewards.Sink {
+	case "ledger":
+		// Rewards are credited in the claim transaction; nothing is dispatched.
+	case "webhook":
+		rewardSink = webhook.NewRewardSink(cfg.Rewards.WebhookURL, cfg.Rewards.WebhookTimeout, log)
+	case "memory":
+		rewardSink = memory.NewRewardSink(log)
+	default:
+		log.Error("unknown reward sink", zap.String("sink", cfg.Rewards.Sink))
+		pool.Close()
+		_ = log.Sync()
+		return nil, fmt.Errorf("unknown reward sink %q", cfg.Rewards.Sink)
+	}
+
+	var rewardDispatcher *service.RewardDispatcher
+	if rewardSink != nil {
+		rewardDispatcher, err = service.NewRewardDispatcher(rewardOutboxRepo, rewardSink, service.RewardDispatcherConfig{
+			Interval:    cfg.Rewards.DispatchInterval,
+			BatchSize:   cfg.Rewards.DispatchBatchSize,
+			Lease:       cfg.Rewards.DispatchLease,
+			MaxAttempts: cfg.Rewards.MaxAttempts,
+			RetryDelay:  cfg.Rewards.RetryDelay,
+			MaxDelay:    cfg.Rewards.RetryMaxDelay,
+		}, log)
+		if err != nil {
+			log.Error("failed to init reward dispatcher", zap.Error(err))
+			pool.Close()
+			_ = log.Sync()
+			return nil, err
+		}
+	}
+
 	grpcAddr := fmt.Sprintf(":%d", cfg.GRPC.Port)
 	listener, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
@@ -84,13 +164,16 @@ func Init() (*App, error) {
 		cfg.GRPC.SubscribeProgressInterval,
 		cfg.GRPC.SubscribeProgressMaxPeriod,
 	))
+	tasksv1.RegisterTaskAdminServiceServer(grpcServer, grpcadapter.NewTaskAdminServer(taskService, log))
 	reflection.Register(grpcServer)
 
 	return &App{
-		Config:     cfg,
-		Log:        log,
-		GRPCServer: grpcServer,
-		Listener:   listener,
+		Config:           cfg,
+		Log:              log,
+		GRPCServer:       grpcServer,
+		Listener:         listener,
+		RewardDispatcher: rewardDispatcher,
+		TaskService:      taskService,
 		close: func() {
 			_ = listener.Close()
 			pool.Close()
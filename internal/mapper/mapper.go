@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -18,29 +20,392 @@ func Task(task *entities.Task) *tasksv1.Task {
 		return nil
 	}
 	return &tasksv1.Task{
-		Id:          task.ID(),
-		Title:       task.Title(),
-		Description: task.Description(),
-		Type:        string(task.Type()),
-		Target:      int32(task.Target()),
-		RewardJson:  task.Reward(),
-		IsActive:    task.IsActive(),
-		CreatedAt:   timestamp(task.CreatedAt()),
+		Id:              task.ID(),
+		Title:           task.Title(),
+		Description:     task.Description(),
+		Type:            string(task.Type()),
+		Target:          int32(task.Target()),
+		RewardJson:      task.Reward(),
+		Reward:          Reward(task.TypedReward()),
+		IsActive:        task.IsActive(),
+		CreatedAt:       timestamp(task.CreatedAt()),
+		StartsAt:        timestamp(task.StartsAt()),
+		EndsAt:          timestamp(task.EndsAt()),
+		ResetPeriod:     string(task.ResetPeriod()),
+		PrerequisiteIds: task.Prerequisites(),
+		Tiers:           Tiers(task.Tiers()),
+		RepeatLimit:     int32(task.RepeatLimit()),
+		Rule:            Rule(task.Rule()),
+		Aggregation:     Aggregation(task.Aggregation()),
+		ChildTaskIds:    task.Children(),
+		QuestIds:        task.QuestIDs(),
+		SeasonXp:        int32(task.SeasonXP()),
+		Streak:          Streak(task.Streak()),
+		StreakIds:       task.StreakIDs(),
+		Scope:           string(task.Scope()),
+		Leaderboard:     Leaderboard(task.Leaderboard()),
 	}
 }
 
+func Tasks(tasks []*entities.Task) []*tasksv1.Task {
+	result := make([]*tasksv1.Task, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, Task(task))
+	}
+	return result
+}
+
+func NewTask(req *tasksv1.CreateTaskRequest) *entities.Task {
+	task := entities.NewTask(
+		"",
+		req.GetTitle(),
+		req.GetDescription(),
+		entities.TaskType(req.GetType()),
+		int(req.GetTarget()),
+		rewardJSON(req.GetReward(), req.GetRewardJson()),
+		req.GetIsActive(),
+		time.Time{},
+	)
+	task.SetSchedule(timeValue(req.GetStartsAt()), timeValue(req.GetEndsAt()))
+	task.SetResetPeriod(entities.ResetPeriod(req.GetResetPeriod()))
+	task.SetPrerequisites(req.GetPrerequisiteIds())
+	task.SetTiers(domainTiers(req.GetTiers()))
+	task.SetRepeatLimit(int(req.GetRepeatLimit()))
+	task.SetRule(domainRule(req.GetRule()))
+	task.SetAggregation(domainAggregation(req.GetAggregation()))
+	task.SetChildren(req.GetChildTaskIds())
+	task.SetSeasonXP(int(req.GetSeasonXp()))
+	task.SetStreak(domainStreak(req.GetStreak()))
+	task.SetScope(entities.TaskScope(req.GetScope()))
+	task.SetLeaderboard(domainLeaderboard(req.GetLeaderboard()))
+	return task
+}
+
+func UpdatedTask(req *tasksv1.UpdateTaskRequest) *entities.Task {
+	task := entities.NewTask(
+		req.GetTaskId(),
+		req.GetTitle(),
+		req.GetDescription(),
+		entities.TaskType(req.GetType()),
+		int(req.GetTarget()),
+		rewardJSON(req.GetReward(), req.GetRewardJson()),
+		req.GetIsActive(),
+		time.Time{},
+	)
+	task.SetSchedule(timeValue(req.GetStartsAt()), timeValue(req.GetEndsAt()))
+	task.SetResetPeriod(entities.ResetPeriod(req.GetResetPeriod()))
+	task.SetPrerequisites(req.GetPrerequisiteIds())
+	task.SetTiers(domainTiers(req.GetTiers()))
+	task.SetRepeatLimit(int(req.GetRepeatLimit()))
+	task.SetRule(domainRule(req.GetRule()))
+	task.SetAggregation(domainAggregation(req.GetAggregation()))
+	task.SetChildren(req.GetChildTaskIds())
+	task.SetSeasonXP(int(req.GetSeasonXp()))
+	task.SetStreak(domainStreak(req.GetStreak()))
+	task.SetScope(entities.TaskScope(req.GetScope()))
+	task.SetLeaderboard(domainLeaderboard(req.GetLeaderboard()))
+	return task
+}
+
+func Tiers(tiers []entities.TaskTier) []*tasksv1.TaskTier {
+	result := make([]*tasksv1.TaskTier, 0, len(tiers))
+	for i, tier := range tiers {
+		result = append(result, &tasksv1.TaskTier{
+			Tier:       int32(i + 1),
+			Target:     int32(tier.Target),
+			RewardJson: tier.Reward,
+			Reward:     Reward(tier.TypedReward()),
+		})
+	}
+	return result
+}
+
+func TierStates(states []entities.TierState) []*tasksv1.TierProgress {
+	result := make([]*tasksv1.TierProgress, 0, len(states))
+	for _, state := range states {
+		result = append(result, &tasksv1.TierProgress{
+			Tier:      int32(state.Tier),
+			Target:    int32(state.Target),
+			Completed: state.Completed,
+			Claimed:   state.Claimed,
+		})
+	}
+	return result
+}
+
+func Rule(rule *entities.TaskRule) *tasksv1.TaskRule {
+	if rule == nil {
+		return nil
+	}
+	eventTypes := make([]string, 0, len(rule.EventTypes))
+	for _, eventType := range rule.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	return &tasksv1.TaskRule{
+		EventTypes: eventTypes,
+		Filters:    rule.Filters,
+		Condition:  rule.Condition,
+	}
+}
+
+func Aggregation(aggregation entities.Aggregation) *tasksv1.Aggregation {
+	result := &tasksv1.Aggregation{
+		Mode:      string(aggregation.Mode),
+		Attribute: aggregation.Attribute,
+	}
+	if aggregation.Window > 0 {
+		result.Window = durationpb.New(aggregation.Window)
+	}
+	for i := range aggregation.Steps {
+		result.Steps = append(result.Steps, Rule(&aggregation.Steps[i]))
+	}
+	return result
+}
+
+func Streak(streak *entities.StreakConfig) *tasksv1.TaskStreak {
+	if streak == nil {
+		return nil
+	}
+	return &tasksv1.TaskStreak{
+		TaskIds:   streak.TaskIDs,
+		GraceDays: int32(streak.GraceDays),
+	}
+}
+
+func Season(season *entities.Season) *tasksv1.Season {
+	if season == nil {
+		return nil
+	}
+	levels := make([]*tasksv1.SeasonLevel, 0, len(season.Levels()))
+	for i, level := range season.Levels() {
+		levels = append(levels, &tasksv1.SeasonLevel{
+			Level:             int32(i + 1),
+			Xp:                int32(level.XP),
+			FreeRewardJson:    level.FreeReward,
+			PremiumRewardJson: level.PremiumReward,
+			FreeReward:        typedReward(level.FreeReward),
+			PremiumReward:     typedReward(level.PremiumReward),
+		})
+	}
+	return &tasksv1.Season{
+		Id:        season.ID(),
+		Name:      season.Name(),
+		StartsAt:  timestamp(season.StartsAt()),
+		EndsAt:    timestamp(season.EndsAt()),
+		Levels:    levels,
+		CreatedAt: timestamp(season.CreatedAt()),
+	}
+}
+
+func NewSeason(req *tasksv1.CreateSeasonRequest) *entities.Season {
+	levels := make([]entities.SeasonLevel, 0, len(req.GetLevels()))
+	for _, level := range req.GetLevels() {
+		levels = append(levels, entities.SeasonLevel{
+			XP:            int(level.GetXp()),
+			FreeReward:    rewardJSON(level.GetFreeReward(), level.GetFreeRewardJson()),
+			PremiumReward: rewardJSON(level.GetPremiumReward(), level.GetPremiumRewardJson()),
+		})
+	}
+	return entities.NewSeason("", req.GetName(), timeValue(req.GetStartsAt()), timeValue(req.GetEndsAt()), levels, time.Time{})
+}
+
+// SeasonProgress maps the user's season state; the season, when known, is
+// used to resolve the reached level.
+func SeasonProgress(season *entities.Season, progress *entities.SeasonProgress) *tasksv1.SeasonProgress {
+	if progress == nil {
+		return nil
+	}
+	level := 0
+	if season != nil {
+		level = season.LevelAt(progress.XP())
+	}
+	return &tasksv1.SeasonProgress{
+		UserId:               progress.UserID(),
+		SeasonId:             progress.SeasonID(),
+		Xp:                   int32(progress.XP()),
+		Level:                int32(level),
+		Premium:              progress.Premium(),
+		ClaimedFreeLevels:    int32Values(progress.ClaimedLevels(entities.SeasonTrackFree)),
+		ClaimedPremiumLevels: int32Values(progress.ClaimedLevels(entities.SeasonTrackPremium)),
+		UpdatedAt:            timestamp(progress.UpdatedAt()),
+	}
+}
+
+func SetUserTimezoneResponse(settings *entities.UserSettings) *tasksv1.SetUserTimezoneResponse {
+	resp := &tasksv1.SetUserTimezoneResponse{
+		UserId:   settings.UserID,
+		Timezone: settings.Timezone,
+	}
+	if settings.PendingTimezone != "" {
+		resp.PendingTimezone = settings.PendingTimezone
+		resp.PendingFrom = timestamp(settings.PendingFrom)
+	}
+	return resp
+}
+
+func ClaimSeasonRewardResponse(season *entities.Season, progress *entities.SeasonProgress, grant *entities.RewardGrant) *tasksv1.ClaimSeasonRewardResponse {
+	resp := &tasksv1.ClaimSeasonRewardResponse{Progress: SeasonProgress(season, progress)}
+	if grant != nil {
+		resp.Reward = typedReward(grant.Reward)
+		resp.RewardJson = grant.Reward
+		resp.GrantId = grant.ID
+	}
+	return resp
+}
+
+func Achievement(achievement *entities.Achievement) *tasksv1.Achievement {
+	if achievement == nil {
+		return nil
+	}
+	criteria := make([]*tasksv1.AchievementCriterion, 0, len(achievement.Criteria()))
+	for _, criterion := range achievement.Criteria() {
+		criteria = append(criteria, &tasksv1.AchievementCriterion{
+			Kind:      string(criterion.Kind),
+			Counter:   criterion.Counter,
+			Threshold: criterion.Threshold,
+			TaskType:  string(criterion.TaskType),
+		})
+	}
+	return &tasksv1.Achievement{
+		Id:          achievement.ID(),
+		Title:       achievement.Title(),
+		Description: achievement.Description(),
+		Badge:       achievement.Badge(),
+		Criteria:    criteria,
+		RewardJson:  achievement.Reward(),
+		Reward:      typedReward(achievement.Reward()),
+		CreatedAt:   timestamp(achievement.CreatedAt()),
+	}
+}
+
+func UserAchievement(achievement entities.UserAchievement) *tasksv1.UserAchievement {
+	return &tasksv1.UserAchievement{
+		Achievement: Achievement(achievement.Achievement),
+		Unlocked:    achievement.Unlocked(),
+		UnlockedAt:  timestamp(achievement.UnlockedAt),
+	}
+}
+
+func NewAchievement(req *tasksv1.CreateAchievementRequest) *entities.Achievement {
+	criteria := make([]entities.AchievementCriterion, 0, len(req.GetCriteria()))
+	for _, criterion := range req.GetCriteria() {
+		criteria = append(criteria, entities.AchievementCriterion{
+			Kind:      entities.AchievementCriterionKind(criterion.GetKind()),
+			Counter:   criterion.GetCounter(),
+			Threshold: criterion.GetThreshold(),
+			TaskType:  entities.TaskType(criterion.GetTaskType()),
+		})
+	}
+	return entities.NewAchievement("", req.GetTitle(), req.GetDescription(), req.GetBadge(), criteria, rewardJSON(req.GetReward(), req.GetRewardJson()), time.Time{})
+}
+
 func Progress(progress *entities.TaskProgress) *tasksv1.TaskProgress {
 	if progress == nil {
 		return nil
 	}
 	return &tasksv1.TaskProgress{
-		Id:        progress.ID(),
-		TaskId:    progress.TaskID(),
-		UserId:    progress.UserID(),
-		Progress:  int32(progress.Progress()),
-		Completed: progress.Completed(),
-		Claimed:   progress.Claimed(),
-		UpdatedAt: timestamp(progress.UpdatedAt()),
+		Id:            progress.ID(),
+		TaskId:        progress.TaskID(),
+		UserId:        progress.UserID(),
+		PeriodKey:     progress.PeriodKey(),
+		Progress:      int32(progress.Progress()),
+		Completed:     progress.Completed(),
+		Claimed:       progress.Claimed(),
+		UpdatedAt:     timestamp(progress.UpdatedAt()),
+		PeriodEndsAt:  timestamp(progress.PeriodEndsAt()),
+		Locked:        progress.Locked(),
+		Tiers:         TierStates(progress.TierStates()),
+		Completions:   int32(progress.Completions()),
+		Claims:        int32(progress.Claims()),
+		StreakCurrent: int32(progress.StreakCurrent()),
+		StreakLongest: int32(progress.StreakLongest()),
+		RoomId:        progress.RoomID(),
+		Contribution:  int32(progress.Contribution()),
+	}
+}
+
+func ClaimRewardResponse(claim *entities.RewardClaim, progress *entities.TaskProgress) *tasksv1.ClaimRewardResponse {
+	return &tasksv1.ClaimRewardResponse{
+		Progress:   Progress(progress),
+		Tier:       int32(claim.Tier),
+		PeriodKey:  claim.PeriodKey,
+		Reward:     typedReward(claim.Reward),
+		RewardJson: claim.Reward,
+		GrantId:    claim.GrantID,
+		Replayed:   claim.Replayed,
+	}
+}
+
+func ClaimAllRewardsResponse(results []entities.TaskClaims, total *entities.Reward) *tasksv1.ClaimAllRewardsResponse {
+	resp := &tasksv1.ClaimAllRewardsResponse{
+		Results:     make([]*tasksv1.TaskClaimResult, 0, len(results)),
+		TotalReward: Reward(total),
+	}
+	for _, result := range results {
+		claimResult := &tasksv1.TaskClaimResult{
+			TaskId:   result.TaskID,
+			Tiers:    make([]int32, 0, len(result.Claims)),
+			Reward:   Reward(result.Reward()),
+			GrantIds: make([]string, 0, len(result.Claims)),
+			Progress: Progress(result.Progress),
+		}
+		for _, claim := range result.Claims {
+			claimResult.Tiers = append(claimResult.Tiers, int32(claim.Tier))
+			if claim.GrantID != "" {
+				claimResult.GrantIds = append(claimResult.GrantIds, claim.GrantID)
+			}
+		}
+		resp.Results = append(resp.Results, claimResult)
+	}
+	return resp
+}
+
+func Group(group *entities.Group) *tasksv1.Group {
+	if group == nil {
+		return nil
+	}
+	return &tasksv1.Group{
+		Id:        group.ID(),
+		Name:      group.Name(),
+		CreatedAt: timestamp(group.CreatedAt()),
+	}
+}
+
+func NewGroup(req *tasksv1.CreateGroupRequest) *entities.Group {
+	return entities.NewGroup("", req.GetName(), time.Time{})
+}
+
+func GroupMember(member entities.GroupMember) *tasksv1.GroupMember {
+	return &tasksv1.GroupMember{
+		GroupId:  member.GroupID,
+		UserId:   member.UserID,
+		JoinedAt: timestamp(member.JoinedAt),
+	}
+}
+
+func RoomProgress(progress *entities.RoomProgress) *tasksv1.RoomProgress {
+	if progress == nil {
+		return nil
+	}
+	contributions := make([]*tasksv1.RoomContribution, 0, len(progress.Contributions))
+	for _, contribution := range progress.Contributions {
+		contributions = append(contributions, &tasksv1.RoomContribution{
+			UserId:    contribution.UserID,
+			Amount:    int32(contribution.Amount),
+			Claimed:   contribution.Claimed,
+			UpdatedAt: timestamp(contribution.UpdatedAt),
+		})
+	}
+	return &tasksv1.RoomProgress{
+		TaskId:        progress.TaskID,
+		RoomId:        progress.RoomID,
+		PeriodKey:     progress.PeriodKey,
+		Progress:      int32(progress.Progress),
+		Target:        int32(progress.Target),
+		Completed:     progress.Completed,
+		CompletedAt:   timestamp(progress.CompletedAt),
+		UpdatedAt:     timestamp(progress.UpdatedAt),
+		Contributions: contributions,
 	}
 }
 
@@ -71,19 +436,28 @@ func Event(event *tasksv1.TaskEvent) (*entities.TaskEvent, error) {
 		}
 	}
 
-	createdAt := time.Time{}
-	if event.CreatedAt != nil {
-		createdAt = event.CreatedAt.AsTime()
-	}
-
-	return entities.NewTaskEvent(
+	domainEvent, err := entities.NewTaskEvent(
 		event.GetEventId(),
 		event.GetUserId(),
 		event.GetRoomId(),
 		entities.TaskEventType(event.GetType()),
 		payload,
-		createdAt,
+		timeValue(event.GetCreatedAt()),
 	)
+	if err != nil {
+		return nil, err
+	}
+	if err := domainEvent.SetTimezone(event.GetTimezone()); err != nil {
+		return nil, err
+	}
+	if event.GetAttributes() != nil {
+		attributes, err := entities.NewEventAttributes(event.GetAttributes().AsMap())
+		if err != nil {
+			return nil, err
+		}
+		domainEvent.SetAttributes(attributes)
+	}
+	return domainEvent, nil
 }
 
 func Error(err error) error {
@@ -92,29 +466,289 @@ func Error(err error) error {
 	}
 	switch {
 	case errors.Is(err, exceptions.ErrTaskNotFound),
-		errors.Is(err, exceptions.ErrProgressNotFound):
+		errors.Is(err, exceptions.ErrProgressNotFound),
+		errors.Is(err, exceptions.ErrSeasonNotFound),
+		errors.Is(err, exceptions.ErrGroupNotFound),
+		errors.Is(err, exceptions.ErrGroupMemberNotFound):
 		return status.Error(codes.NotFound, err.Error())
 	case errors.Is(err, exceptions.ErrTaskNotCompleted),
 		errors.Is(err, exceptions.ErrRewardAlreadyClaimed),
-		errors.Is(err, exceptions.ErrTaskInactive):
+		errors.Is(err, exceptions.ErrTaskInactive),
+		errors.Is(err, exceptions.ErrTaskNotAvailable),
+		errors.Is(err, exceptions.ErrTaskLocked),
+		errors.Is(err, exceptions.ErrEventConditionNotMet),
+		errors.Is(err, exceptions.ErrQuestProgressDerived),
+		errors.Is(err, exceptions.ErrStreakProgressDerived),
+		errors.Is(err, exceptions.ErrSeasonOverlap),
+		errors.Is(err, exceptions.ErrSeasonLevelNotReached),
+		errors.Is(err, exceptions.ErrSeasonPremiumRequired),
+		errors.Is(err, exceptions.ErrGroupMembershipExists),
+		errors.Is(err, exceptions.ErrGroupMembershipRequired),
+		errors.Is(err, exceptions.ErrLeaderboardDisabled),
+		errors.Is(err, exceptions.ErrInsufficientBalance):
 		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, exceptions.ErrLedgerReferenceExists),
+		errors.Is(err, exceptions.ErrIdempotencyKeyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
 	case errors.Is(err, exceptions.ErrEventNil),
+		errors.Is(err, exceptions.ErrTaskTitleRequired),
+		errors.Is(err, exceptions.ErrTaskTypeInvalid),
+		errors.Is(err, exceptions.ErrTaskTargetInvalid),
+		errors.Is(err, exceptions.ErrTaskRewardInvalid),
+		errors.Is(err, exceptions.ErrTaskScheduleInvalid),
+		errors.Is(err, exceptions.ErrTaskResetPeriodInvalid),
+		errors.Is(err, exceptions.ErrTimezoneInvalid),
+		errors.Is(err, exceptions.ErrTaskRepeatLimitInvalid),
+		errors.Is(err, exceptions.ErrTaskRuleInvalid),
+		errors.Is(err, exceptions.ErrTaskConditionInvalid),
+		errors.Is(err, exceptions.ErrTaskAggregationInvalid),
+		errors.Is(err, exceptions.ErrTaskTiersInvalid),
+		errors.Is(err, exceptions.ErrTaskTierInvalid),
+		errors.Is(err, exceptions.ErrTaskPrerequisiteInvalid),
+		errors.Is(err, exceptions.ErrTaskPrerequisiteNotFound),
+		errors.Is(err, exceptions.ErrTaskPrerequisiteCycle),
+		errors.Is(err, exceptions.ErrTaskQuestInvalid),
+		errors.Is(err, exceptions.ErrTaskQuestChildNotFound),
+		errors.Is(err, exceptions.ErrTaskQuestCycle),
+		errors.Is(err, exceptions.ErrTaskSeasonXPInvalid),
+		errors.Is(err, exceptions.ErrTaskStreakInvalid),
+		errors.Is(err, exceptions.ErrTaskStreakSourceInvalid),
+		errors.Is(err, exceptions.ErrSeasonInvalid),
+		errors.Is(err, exceptions.ErrSeasonLevelInvalid),
+		errors.Is(err, exceptions.ErrSeasonTrackInvalid),
+		errors.Is(err, exceptions.ErrAchievementInvalid),
+		errors.Is(err, exceptions.ErrTaskScopeInvalid),
+		errors.Is(err, exceptions.ErrTaskSharedSourceInvalid),
+		errors.Is(err, exceptions.ErrEventRoomRequired),
+		errors.Is(err, exceptions.ErrGroupInvalid),
+		errors.Is(err, exceptions.ErrTaskLeaderboardInvalid),
+		errors.Is(err, exceptions.ErrLedgerSpendInvalid),
+		errors.Is(err, exceptions.ErrLedgerRewardUnsupported),
+		errors.Is(err, exceptions.ErrLedgerPageTokenInvalid),
+		errors.Is(err, exceptions.ErrIdempotencyKeyReused),
 		errors.Is(err, exceptions.ErrEventIDRequired),
 		errors.Is(err, exceptions.ErrEventUserIDRequired),
 		errors.Is(err, exceptions.ErrEventTypeRequired),
 		errors.Is(err, exceptions.ErrEventPayloadInvalid),
-		errors.Is(err, exceptions.ErrEventTaskIDRequired),
 		errors.Is(err, exceptions.ErrEventAmountInvalid),
-		errors.Is(err, exceptions.ErrUnsupportedEventType):
+		errors.Is(err, exceptions.ErrEventAttributesInvalid),
+		errors.Is(err, exceptions.ErrEventAttributeMissing),
+		errors.Is(err, exceptions.ErrEventValueOutOfRange),
+		errors.Is(err, exceptions.ErrEventTooLate):
 		return status.Error(codes.InvalidArgument, err.Error())
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
 }
 
+func domainTiers(tiers []*tasksv1.TaskTier) []entities.TaskTier {
+	result := make([]entities.TaskTier, 0, len(tiers))
+	for _, tier := range tiers {
+		result = append(result, entities.TaskTier{
+			Target: int(tier.GetTarget()),
+			Reward: rewardJSON(tier.GetReward(), tier.GetRewardJson()),
+		})
+	}
+	return result
+}
+
+func domainRule(rule *tasksv1.TaskRule) *entities.TaskRule {
+	if rule == nil {
+		return nil
+	}
+	eventTypes := make([]entities.TaskEventType, 0, len(rule.GetEventTypes()))
+	for _, eventType := range rule.GetEventTypes() {
+		eventTypes = append(eventTypes, entities.TaskEventType(eventType))
+	}
+	return &entities.TaskRule{
+		EventTypes: eventTypes,
+		Filters:    rule.GetFilters(),
+		Condition:  rule.GetCondition(),
+	}
+}
+
+func domainAggregation(aggregation *tasksv1.Aggregation) entities.Aggregation {
+	result := entities.Aggregation{
+		Mode:      entities.AggregationMode(aggregation.GetMode()),
+		Attribute: aggregation.GetAttribute(),
+	}
+	if window := aggregation.GetWindow(); window != nil {
+		result.Window = window.AsDuration()
+	}
+	for _, step := range aggregation.GetSteps() {
+		result.Steps = append(result.Steps, *domainRule(step))
+	}
+	return result
+}
+
+func domainStreak(streak *tasksv1.TaskStreak) *entities.StreakConfig {
+	if streak == nil {
+		return nil
+	}
+	return &entities.StreakConfig{
+		TaskIDs:   streak.GetTaskIds(),
+		GraceDays: int(streak.GetGraceDays()),
+	}
+}
+
+func Leaderboard(leaderboard *entities.LeaderboardConfig) *tasksv1.TaskLeaderboard {
+	if leaderboard == nil {
+		return nil
+	}
+	return &tasksv1.TaskLeaderboard{Period: string(leaderboard.Period)}
+}
+
+func domainLeaderboard(leaderboard *tasksv1.TaskLeaderboard) *entities.LeaderboardConfig {
+	if leaderboard == nil {
+		return nil
+	}
+	return &entities.LeaderboardConfig{Period: entities.ResetPeriod(leaderboard.GetPeriod())}
+}
+
+func LeaderboardResponse(board *entities.Leaderboard) *tasksv1.GetLeaderboardResponse {
+	entries := make([]*tasksv1.LeaderboardEntry, 0, len(board.Entries))
+	for _, entry := range board.Entries {
+		entries = append(entries, &tasksv1.LeaderboardEntry{
+			Rank:        int32(entry.Rank),
+			UserId:      entry.UserID,
+			Score:       entry.Score,
+			CompletedAt: timestamp(entry.CompletedAt),
+		})
+	}
+	return &tasksv1.GetLeaderboardResponse{
+		TaskId:    board.TaskID,
+		PeriodKey: board.PeriodKey,
+		Total:     int32(board.Total),
+		Entries:   entries,
+	}
+}
+
+func Reward(reward *entities.Reward) *tasksv1.Reward {
+	if reward == nil {
+		return nil
+	}
+	result := &tasksv1.Reward{
+		Currencies: make([]*tasksv1.CurrencyReward, 0, len(reward.Currencies)),
+		Items:      make([]*tasksv1.ItemReward, 0, len(reward.Items)),
+		Xp:         reward.XP,
+		Bundles:    make([]*tasksv1.BundleReward, 0, len(reward.Bundles)),
+	}
+	for _, currency := range reward.Currencies {
+		result.Currencies = append(result.Currencies, &tasksv1.CurrencyReward{Currency: currency.Currency, Amount: currency.Amount})
+	}
+	for _, item := range reward.Items {
+		result.Items = append(result.Items, &tasksv1.ItemReward{ItemId: item.ItemID, Quantity: int32(item.Quantity)})
+	}
+	for _, bundle := range reward.Bundles {
+		result.Bundles = append(result.Bundles, &tasksv1.BundleReward{BundleId: bundle.BundleID, Quantity: int32(bundle.Quantity)})
+	}
+	return result
+}
+
+func typedReward(raw json.RawMessage) *tasksv1.Reward {
+	reward, _ := entities.ParseReward(raw)
+	return Reward(reward)
+}
+
+func domainReward(reward *tasksv1.Reward) *entities.Reward {
+	if reward == nil {
+		return nil
+	}
+	result := &entities.Reward{XP: reward.GetXp()}
+	for _, currency := range reward.GetCurrencies() {
+		result.Currencies = append(result.Currencies, entities.CurrencyReward{Currency: currency.GetCurrency(), Amount: currency.GetAmount()})
+	}
+	for _, item := range reward.GetItems() {
+		result.Items = append(result.Items, entities.ItemReward{ItemID: item.GetItemId(), Quantity: int(item.GetQuantity())})
+	}
+	for _, bundle := range reward.GetBundles() {
+		result.Bundles = append(result.Bundles, entities.BundleReward{BundleID: bundle.GetBundleId(), Quantity: int(bundle.GetQuantity())})
+	}
+	return result
+}
+
+// rewardJSON returns the stored form of a reward from a request, preferring
+// the typed reward over the deprecated raw JSON.
+func rewardJSON(reward *tasksv1.Reward, raw []byte) json.RawMessage {
+	if reward != nil {
+		return entities.EncodeReward(domainReward(reward))
+	}
+	return raw
+}
+
+func Balance(balance entities.Balance) *tasksv1.Balance {
+	return &tasksv1.Balance{
+		AssetKind: string(balance.AssetKind),
+		Asset:     balance.Asset,
+		Amount:    balance.Amount,
+		UpdatedAt: timestamp(balance.UpdatedAt),
+	}
+}
+
+// BalancesResponse splits balances into currencies and inventory items.
+func BalancesResponse(userID string, balances []entities.Balance) *tasksv1.GetBalancesResponse {
+	resp := &tasksv1.GetBalancesResponse{
+		UserId:     userID,
+		Currencies: make([]*tasksv1.Balance, 0, len(balances)),
+		Items:      make([]*tasksv1.Balance, 0),
+	}
+	for _, balance := range balances {
+		if balance.AssetKind == entities.AssetItem {
+			resp.Items = append(resp.Items, Balance(balance))
+			continue
+		}
+		resp.Currencies = append(resp.Currencies, Balance(balance))
+	}
+	return resp
+}
+
+func LedgerTransaction(tx *entities.LedgerTransaction) *tasksv1.LedgerTransaction {
+	entries := make([]*tasksv1.LedgerEntry, 0, len(tx.Entries))
+	for _, entry := range tx.Entries {
+		entries = append(entries, &tasksv1.LedgerEntry{
+			Account:   entry.Account,
+			System:    entry.System,
+			AssetKind: string(entry.AssetKind),
+			Asset:     entry.Asset,
+			Amount:    entry.Amount,
+		})
+	}
+	return &tasksv1.LedgerTransaction{
+		Id:        tx.ID,
+		UserId:    tx.UserID,
+		Kind:      string(tx.Kind),
+		Reference: tx.Reference,
+		Entries:   entries,
+		CreatedAt: timestamp(tx.CreatedAt),
+	}
+}
+
+func LedgerTransactions(transactions []*entities.LedgerTransaction) []*tasksv1.LedgerTransaction {
+	result := make([]*tasksv1.LedgerTransaction, 0, len(transactions))
+	for _, tx := range transactions {
+		result = append(result, LedgerTransaction(tx))
+	}
+	return result
+}
+
+func int32Values(values []int) []int32 {
+	result := make([]int32, 0, len(values))
+	for _, value := range values {
+		result = append(result, int32(value))
+	}
+	return result
+}
+
 func timestamp(t time.Time) *timestamppb.Timestamp {
 	if t.IsZero() {
 		return nil
 	}
 	return timestamppb.New(t)
 }
+
+func timeValue(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
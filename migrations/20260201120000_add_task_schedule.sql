@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS starts_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS ends_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_schedule_check CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_schedule_check;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS ends_at,
+    DROP COLUMN IF EXISTS starts_at;
+
+-- +goose StatementEnd
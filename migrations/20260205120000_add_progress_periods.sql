@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS reset_period TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE task_progress
+    ADD COLUMN IF NOT EXISTS period_key TEXT NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS idx_task_progress_user_task;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_progress_user_task_period
+ON task_progress(user_id, task_id, period_key);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_task_progress_user_task_period;
+
+DELETE FROM task_progress
+WHERE period_key <> '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_progress_user_task
+ON task_progress(user_id, task_id);
+
+ALTER TABLE task_progress DROP COLUMN IF EXISTS period_key;
+ALTER TABLE tasks DROP COLUMN IF EXISTS reset_period;
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS task_prerequisites (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    prerequisite_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, prerequisite_id),
+    CHECK (task_id <> prerequisite_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_prerequisites_prerequisite_id
+ON task_prerequisites(prerequisite_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_task_prerequisites_prerequisite_id;
+DROP TABLE IF EXISTS task_prerequisites;
+
+-- +goose StatementEnd
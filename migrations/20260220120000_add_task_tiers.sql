@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS tiers JSONB;
+
+ALTER TABLE task_progress
+    ADD COLUMN IF NOT EXISTS claimed_tiers INTEGER[] NOT NULL DEFAULT '{}';
+
+UPDATE task_progress
+SET claimed_tiers = '{1}'
+WHERE claimed = true;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE task_progress DROP COLUMN IF EXISTS claimed_tiers;
+ALTER TABLE tasks DROP COLUMN IF EXISTS tiers;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS repeat_limit INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE task_progress
+    ADD COLUMN IF NOT EXISTS completions INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS claimed_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE task_progress
+SET completions = CASE WHEN completed THEN 1 ELSE 0 END,
+    claimed_count = CASE WHEN claimed THEN 1 ELSE 0 END;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE task_progress
+    DROP COLUMN IF EXISTS claimed_count,
+    DROP COLUMN IF EXISTS completions;
+ALTER TABLE tasks DROP COLUMN IF EXISTS repeat_limit;
+
+-- +goose StatementEnd
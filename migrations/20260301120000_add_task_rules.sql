@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS rule JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_rule_event_types
+ON tasks USING GIN ((rule -> 'event_types'))
+WHERE is_active = true;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_tasks_rule_event_types;
+ALTER TABLE tasks DROP COLUMN IF EXISTS rule;
+
+-- +goose StatementEnd
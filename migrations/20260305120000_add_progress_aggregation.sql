@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS aggregation_mode TEXT NOT NULL DEFAULT 'sum',
+    ADD COLUMN IF NOT EXISTS aggregation_attribute TEXT;
+
+CREATE TABLE IF NOT EXISTS task_progress_values (
+    user_id TEXT NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    period_key TEXT NOT NULL DEFAULT '',
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, task_id, period_key, value)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS task_progress_values;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS aggregation_attribute,
+    DROP COLUMN IF EXISTS aggregation_mode;
+
+-- +goose StatementEnd
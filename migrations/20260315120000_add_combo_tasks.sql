@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS aggregation_window_seconds BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS aggregation_steps JSONB;
+
+CREATE TABLE IF NOT EXISTS task_progress_marks (
+    user_id TEXT NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    period_key TEXT NOT NULL DEFAULT '',
+    event_id TEXT NOT NULL,
+    step INT NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, task_id, period_key, event_id, step)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_progress_marks_occurred_at
+    ON task_progress_marks (user_id, task_id, period_key, occurred_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_task_progress_marks_occurred_at;
+DROP TABLE IF EXISTS task_progress_marks;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS aggregation_steps,
+    DROP COLUMN IF EXISTS aggregation_window_seconds;
+
+-- +goose StatementEnd
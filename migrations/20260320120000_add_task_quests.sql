@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS task_quest_children (
+    quest_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    child_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (quest_id, child_id),
+    CHECK (quest_id <> child_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_quest_children_child_id
+ON task_quest_children(child_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_task_quest_children_child_id;
+DROP TABLE IF EXISTS task_quest_children;
+
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS season_xp INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS seasons (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    levels JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_seasons_period ON seasons (starts_at, ends_at);
+
+CREATE TABLE IF NOT EXISTS season_progress (
+    user_id TEXT NOT NULL,
+    season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    xp INT NOT NULL DEFAULT 0,
+    premium BOOLEAN NOT NULL DEFAULT false,
+    claimed_free INT[] NOT NULL DEFAULT '{}',
+    claimed_premium INT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, season_id)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS season_progress;
+DROP INDEX IF EXISTS idx_seasons_period;
+DROP TABLE IF EXISTS seasons;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS season_xp;
+
+-- +goose StatementEnd
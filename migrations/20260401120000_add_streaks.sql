@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS streak JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_streak_task_ids
+ON tasks USING GIN ((streak->'task_ids'));
+
+CREATE TABLE IF NOT EXISTS user_streaks (
+    user_id TEXT NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    current_streak INT NOT NULL DEFAULT 0,
+    longest_streak INT NOT NULL DEFAULT 0,
+    last_day TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, task_id)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS user_streaks;
+DROP INDEX IF EXISTS idx_tasks_streak_task_ids;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS streak;
+
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS scope TEXT NOT NULL DEFAULT 'user';
+
+CREATE TABLE IF NOT EXISTS room_progress (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    room_id TEXT NOT NULL,
+    period_key TEXT NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    target INTEGER NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT false,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, room_id, period_key)
+);
+
+CREATE TABLE IF NOT EXISTS room_contributions (
+    task_id UUID NOT NULL,
+    room_id TEXT NOT NULL,
+    period_key TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    amount INTEGER NOT NULL DEFAULT 0,
+    claimed BOOLEAN NOT NULL DEFAULT false,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, room_id, period_key, user_id),
+    FOREIGN KEY (task_id, room_id, period_key) REFERENCES room_progress(task_id, room_id, period_key) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_contributions_user
+ON room_contributions(user_id, task_id, period_key);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_room_contributions_user;
+DROP TABLE IF EXISTS room_contributions;
+DROP TABLE IF EXISTS room_progress;
+ALTER TABLE tasks DROP COLUMN IF EXISTS scope;
+
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per user: a user belongs to at most one group at a time.
+CREATE TABLE IF NOT EXISTS group_members (
+    user_id TEXT PRIMARY KEY,
+    group_id UUID NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_members_group_id
+ON group_members(group_id);
+
+-- Group tasks keep their shared progress in room_progress and
+-- room_contributions, with room_id holding the group ID.
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_group_members_group_id;
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
+
+-- +goose StatementEnd
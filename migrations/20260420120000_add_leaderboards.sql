@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS leaderboard JSONB;
+
+CREATE TABLE IF NOT EXISTS leaderboard_scores (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    period_key TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    score BIGINT NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    tie_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (task_id, period_key, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_scores_rank
+ON leaderboard_scores(task_id, period_key, score DESC, tie_at, user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_leaderboard_scores_rank;
+DROP TABLE IF EXISTS leaderboard_scores;
+ALTER TABLE tasks DROP COLUMN IF EXISTS leaderboard;
+
+-- +goose StatementEnd
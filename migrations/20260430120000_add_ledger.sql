@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    reference TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, kind, reference)
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES ledger_transactions(id),
+    account TEXT NOT NULL,
+    asset_kind TEXT NOT NULL,
+    asset TEXT NOT NULL,
+    amount BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ledger_balances (
+    user_id TEXT NOT NULL,
+    asset_kind TEXT NOT NULL,
+    asset TEXT NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, asset_kind, asset)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_user
+ON ledger_transactions(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction
+ON ledger_entries(transaction_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_ledger_entries_transaction;
+DROP INDEX IF EXISTS idx_ledger_transactions_user;
+DROP TABLE IF EXISTS ledger_balances;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS reward_claims (
+    user_id TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks(id),
+    period_key TEXT NOT NULL,
+    tier INT NOT NULL DEFAULT 0,
+    room_id TEXT,
+    reward JSONB,
+    grant_id UUID REFERENCES reward_outbox(id),
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS reward_claims;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE ledger_entries ADD COLUMN IF NOT EXISTS system BOOLEAN NOT NULL DEFAULT false;
+
+-- Entries on any account other than the transaction's user are system entries.
+UPDATE ledger_entries e
+SET system = true
+FROM ledger_transactions t
+WHERE t.id = e.transaction_id AND e.account <> t.user_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE ledger_entries DROP COLUMN IF EXISTS system;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Grants are no longer only for tasks: source names what the reward is for and
+-- source_id the task or season. task_id stays set for task grants.
+ALTER TABLE reward_outbox ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'task';
+ALTER TABLE reward_outbox ADD COLUMN IF NOT EXISTS source_id TEXT;
+
+UPDATE reward_outbox SET source_id = task_id::text WHERE source_id IS NULL;
+
+ALTER TABLE reward_outbox ALTER COLUMN source_id SET NOT NULL;
+ALTER TABLE reward_outbox ALTER COLUMN task_id DROP NOT NULL;
+ALTER TABLE reward_outbox ADD CONSTRAINT reward_outbox_task_source
+    CHECK ((source = 'task') = (task_id IS NOT NULL));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM reward_outbox WHERE task_id IS NULL;
+ALTER TABLE reward_outbox DROP CONSTRAINT IF EXISTS reward_outbox_task_source;
+ALTER TABLE reward_outbox ALTER COLUMN task_id SET NOT NULL;
+ALTER TABLE reward_outbox DROP COLUMN IF EXISTS source_id;
+ALTER TABLE reward_outbox DROP COLUMN IF EXISTS source;
+
+-- +goose StatementEnd
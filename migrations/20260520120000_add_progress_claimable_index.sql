@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Tiered and repeatable rows can have rewards left to claim before they are
+-- completed or after their first claim; every such row is still unclaimed.
+CREATE INDEX IF NOT EXISTS idx_task_progress_user_unclaimed
+ON task_progress(user_id, updated_at)
+WHERE claimed = false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_task_progress_user_unclaimed;
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A changed timezone applies from pending_from; until then timezone is used.
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS pending_timezone TEXT,
+    ADD COLUMN IF NOT EXISTS pending_from TIMESTAMP WITH TIME ZONE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+UPDATE user_settings
+SET timezone = pending_timezone
+WHERE pending_timezone IS NOT NULL AND pending_from <= NOW();
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS pending_from,
+    DROP COLUMN IF EXISTS pending_timezone;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The session expiry sweep closes the oldest open sessions first.
+CREATE INDEX IF NOT EXISTS idx_user_sessions_started_at
+ON user_sessions(started_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_user_sessions_started_at;
+
+-- +goose StatementEnd